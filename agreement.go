@@ -0,0 +1,98 @@
+package societyai
+
+// AgreementStats mesure l'accord entre les réponses des agents regroupées par modèle,
+// pour quantifier la diversité qu'apporte réellement l'utilisation de plusieurs modèles
+type AgreementStats struct {
+	// Models liste les modèles dans l'ordre de leur première apparition parmi les agents
+	Models []string
+	// Matrix[i][j] est la similarité moyenne entre les réponses des agents des modèles i et j.
+	// La diagonale compare les agents d'un même modèle entre eux (1 lorsque le modèle n'a qu'un agent).
+	Matrix [][]float64
+	// WithinModelConsistency est la similarité moyenne des paires d'agents d'un même modèle
+	// (1 lorsqu'aucun modèle n'a plusieurs agents)
+	WithinModelConsistency float64
+	// BetweenModelDivergence vaut 1 moins la similarité moyenne des paires d'agents de modèles différents
+	// (0 lorsqu'un seul modèle est utilisé)
+	BetweenModelDivergence float64
+}
+
+// ComputeAgreement calcule les statistiques d'accord entre modèles à partir des résultats des agents,
+// en ignorant les agents écartés ou en échec (similarity : JaccardSimilarity si nil)
+func ComputeAgreement(results []AgentResult, similarity Similarity) *AgreementStats {
+	if similarity == nil {
+		similarity = JaccardSimilarity
+	}
+
+	// Regrouper les réponses par modèle
+	var models []string
+	indexes := make(map[string]int)
+	var outputs [][]string
+	for _, result := range results {
+		if result.Skipped || result.Err != nil {
+			continue
+		}
+		i, ok := indexes[result.ModelName]
+		if !ok {
+			i = len(models)
+			indexes[result.ModelName] = i
+			models = append(models, result.ModelName)
+			outputs = append(outputs, nil)
+		}
+		outputs[i] = append(outputs[i], result.Output)
+	}
+
+	stats := &AgreementStats{Models: models, Matrix: make([][]float64, len(models))}
+	for i := range stats.Matrix {
+		stats.Matrix[i] = make([]float64, len(models))
+	}
+
+	var withinTotal, betweenTotal float64
+	var withinPairs, betweenPairs int
+	for i := range models {
+		for j := i; j < len(models); j++ {
+			total, pairs := 0.0, 0
+			for a, first := range outputs[i] {
+				start := 0
+				if i == j {
+					// Chaque paire d'agents distincts du même modèle n'est comptée qu'une fois
+					start = a + 1
+				}
+				for _, second := range outputs[j][start:] {
+					total += similarity(first, second)
+					pairs++
+				}
+			}
+
+			mean := 1.0
+			if pairs > 0 {
+				mean = total / float64(pairs)
+			}
+			stats.Matrix[i][j], stats.Matrix[j][i] = mean, mean
+
+			if i == j {
+				withinTotal += total
+				withinPairs += pairs
+			} else {
+				betweenTotal += total
+				betweenPairs += pairs
+			}
+		}
+	}
+
+	stats.WithinModelConsistency = 1
+	if withinPairs > 0 {
+		stats.WithinModelConsistency = withinTotal / float64(withinPairs)
+	}
+	if betweenPairs > 0 {
+		stats.BetweenModelDivergence = 1 - betweenTotal/float64(betweenPairs)
+	}
+	return stats
+}
+
+// agreementStats calcule les statistiques d'accord de l'exécution lorsque Config.ComputeAgreementStats est activé
+func (s *SocietyGroup) agreementStats() *AgreementStats {
+	if s.config == nil || !s.config.ComputeAgreementStats {
+		return nil
+	}
+	return ComputeAgreement(s.agentResults, s.similarity())
+}
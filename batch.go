@@ -0,0 +1,88 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// batchGroup regroupe les agents partageant un même modèle capable de traiter des lots
+type batchGroup struct {
+	once     sync.Once
+	calls    *atomic.Int64
+	usage    *usageCounter
+	compress func(ctx context.Context, model AIModel, prompt string) (string, error)
+	model    BatchModel
+	agents   []*Agent
+	results  []string
+	err      error
+}
+
+// process retourne la réponse destinée à l'agent, en déclenchant l'appel groupé
+// lors de la première demande émanant du groupe
+func (g *batchGroup) process(ctx context.Context, a *Agent) (string, error) {
+	g.once.Do(func() {
+		prompts := make([]string, len(g.agents))
+		for i, agent := range g.agents {
+			if prompts[i], g.err = g.compress(ctx, agent.Model, agent.Prompt); g.err != nil {
+				return
+			}
+		}
+
+		g.calls.Add(1)
+		g.results, g.err = g.model.ProcessBatch(ctx, prompts)
+		g.usage.record(g.model)
+		if g.err == nil && len(g.results) != len(prompts) {
+			g.err = fmt.Errorf("le lot a retourné %d réponses pour %d prompts", len(g.results), len(prompts))
+		}
+	})
+
+	if g.err != nil {
+		return "", g.err
+	}
+	for i, agent := range g.agents {
+		if agent == a {
+			return g.results[i], nil
+		}
+	}
+	return "", fmt.Errorf("agent %d absent du lot", a.ID)
+}
+
+// batchGroups associe chaque agent dont le modèle implémente BatchModel au lot de son modèle.
+// Seuls les modèles partagés par au moins deux agents donnent lieu à un appel groupé ;
+// les autres agents sont traités individuellement via Process.
+// Chaque appel groupé est comptabilisé une seule fois dans calls, sa consommation dans usage ;
+// compress est appliqué au prompt de chaque agent avant l'envoi du lot.
+func batchGroups(agents []*Agent, calls *atomic.Int64, usage *usageCounter,
+	compress func(ctx context.Context, model AIModel, prompt string) (string, error)) map[*Agent]*batchGroup {
+	byModel := make(map[AIModel]*batchGroup)
+	var order []*batchGroup
+
+	for _, agent := range agents {
+		batcher, ok := agent.Model.(BatchModel)
+		if !ok || !reflect.TypeOf(agent.Model).Comparable() {
+			continue
+		}
+		group, exists := byModel[agent.Model]
+		if !exists {
+			group = &batchGroup{model: batcher, calls: calls, usage: usage, compress: compress}
+			byModel[agent.Model] = group
+			order = append(order, group)
+		}
+		group.agents = append(group.agents, agent)
+	}
+
+	groups := make(map[*Agent]*batchGroup)
+	for _, group := range order {
+		if len(group.agents) < 2 {
+			continue
+		}
+		for _, agent := range group.agents {
+			groups[agent] = group
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,93 @@
+package societyai_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/benoitpetit/societyai"
+)
+
+// batchModel traite les lots de prompts et accepte des paramètres de génération
+type batchModel struct {
+	mu      sync.Mutex
+	batches [][]string
+	single  []societyai.ProcessOptions
+}
+
+func (m *batchModel) Name() string {
+	return "batch"
+}
+
+func (m *batchModel) Process(ctx context.Context, prompt string) (string, error) {
+	return m.ProcessWithOptions(ctx, prompt, societyai.ProcessOptions{})
+}
+
+func (m *batchModel) ProcessWithOptions(ctx context.Context, prompt string, opts societyai.ProcessOptions) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.single = append(m.single, opts)
+	return "réponse", nil
+}
+
+func (m *batchModel) ProcessBatch(ctx context.Context, prompts []string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches = append(m.batches, prompts)
+	results := make([]string, len(prompts))
+	for i := range prompts {
+		results[i] = "réponse"
+	}
+	return results, nil
+}
+
+// truncateCompressor tronque les prompts trop longs sans appel au modèle
+type truncateCompressor struct{}
+
+func (truncateCompressor) Compress(ctx context.Context, text string, targetChars int) (string, error) {
+	return string([]rune(text)[:targetChars]), nil
+}
+
+func TestBatchKeepsGenerationParameters(t *testing.T) {
+	model := &batchModel{}
+	config := societyai.NewConfig("Comment organiser une astreinte ?", 3)
+	if _, err := societyai.RunSocietyDetailed(context.Background(), config, []societyai.AIModel{model}); err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+
+	if len(model.batches) != 0 {
+		t.Errorf("%d lot(s) envoyé(s), les températures des agents auraient été perdues", len(model.batches))
+	}
+	if len(model.single) != config.AgentCount {
+		t.Fatalf("%d appel(s) individuel(s), %d attendu(s)", len(model.single), config.AgentCount)
+	}
+	for i, opts := range model.single {
+		if opts.Temperature == 0 {
+			t.Errorf("appel %d sans température", i)
+		}
+	}
+}
+
+func TestBatchCompressesPrompts(t *testing.T) {
+	model := &batchModel{}
+	config := societyai.NewConfig("Comment organiser une astreinte ?", 3)
+	config.DisableTemperatureSpread = true
+	config.MaxPromptChars = 40
+	config.PromptCompressor = truncateCompressor{}
+	result, err := societyai.RunSocietyDetailed(context.Background(), config, []societyai.AIModel{model})
+	if err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+
+	if len(model.batches) != 1 || len(model.single) != 0 {
+		t.Fatalf("%d lot(s) et %d appel(s) individuel(s), un seul lot attendu", len(model.batches), len(model.single))
+	}
+	for i, prompt := range model.batches[0] {
+		if n := len([]rune(prompt)); n > config.MaxPromptChars {
+			t.Errorf("prompt %d du lot de %d caractères, au plus %d attendus", i, n, config.MaxPromptChars)
+		}
+	}
+	if result.ModelCalls != 1 {
+		t.Errorf("ModelCalls vaut %d, un appel groupé attendu", result.ModelCalls)
+	}
+}
@@ -0,0 +1,55 @@
+package societyai
+
+import "sort"
+
+// SynthesisBias détermine l'ordre dans lequel les réponses des agents sont présentées au modèle de synthèse
+type SynthesisBias int
+
+// Biais de synthèse disponibles
+const (
+	// SynthesisBiasNone présente les réponses dans l'ordre des agents (comportement par défaut)
+	SynthesisBiasNone SynthesisBias = iota
+	// SynthesisBiasRecency présente d'abord les réponses terminées le plus tard
+	SynthesisBiasRecency
+	// SynthesisBiasDetail présente d'abord les réponses les plus longues
+	SynthesisBiasDetail
+)
+
+// synthesisInputs retourne les résultats des agents non écartés, ordonnés selon Config.SynthesisBias
+func (s *SocietyGroup) synthesisInputs() []AgentResult {
+	active := s.activeResults()
+
+	bias := SynthesisBiasNone
+	if s.config != nil {
+		bias = s.config.SynthesisBias
+	}
+	switch bias {
+	case SynthesisBiasRecency:
+		sort.SliceStable(active, func(i, j int) bool {
+			return active[i].CompletedAt.After(active[j].CompletedAt)
+		})
+	case SynthesisBiasDetail:
+		sort.SliceStable(active, func(i, j int) bool {
+			return len([]rune(active[i].Output)) > len([]rune(active[j].Output))
+		})
+	}
+
+	return active
+}
+
+// synthesisBiasInstruction retourne la consigne expliquant au modèle de synthèse l'ordre des réponses
+func synthesisBiasInstruction(lang Language, bias SynthesisBias) string {
+	switch bias {
+	case SynthesisBiasRecency:
+		if lang == LanguageEnglish {
+			return "The perspectives are listed from the most recent to the oldest: give more weight to the first ones."
+		}
+		return "Les perspectives sont présentées de la plus récente à la plus ancienne : accorde davantage de poids aux premières."
+	case SynthesisBiasDetail:
+		if lang == LanguageEnglish {
+			return "The perspectives are listed from the most detailed to the least detailed: give more weight to the first ones."
+		}
+		return "Les perspectives sont présentées de la plus détaillée à la moins détaillée : accorde davantage de poids aux premières."
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package societyai
+
+// TokenCounter estime le nombre de tokens d'un texte
+type TokenCounter func(text string) int
+
+// ApproximateTokenCounter estime le nombre de tokens à raison d'un token pour quatre caractères
+func ApproximateTokenCounter(text string) int {
+	return (len([]rune(text)) + 3) / 4
+}
+
+// SynthesisDropPolicy détermine l'ordre dans lequel les agents sont écartés de la synthèse
+// lorsque leurs réponses dépassent Config.MaxSynthesisInputTokens
+type SynthesisDropPolicy int
+
+// Politiques d'écartement des agents de la synthèse
+const (
+	// DropMostRedundant écarte d'abord la réponse la plus similaire aux autres (voir Similarity),
+	// dont l'apport propre est vraisemblablement le plus faible (comportement par défaut)
+	DropMostRedundant SynthesisDropPolicy = iota
+	// DropLongest écarte d'abord la réponse la plus longue
+	DropLongest
+	// DropLast écarte d'abord les derniers agents
+	DropLast
+)
+
+// fitSynthesisBudget écarte des agents, un par un selon Config.SynthesisDropPolicy, jusqu'à ce que
+// leurs réponses tiennent dans Config.MaxSynthesisInputTokens. Au moins un agent est conservé.
+// Les identifiants des agents écartés sont enregistrés dans s.droppedAgents.
+func (s *SocietyGroup) fitSynthesisBudget(inputs []AgentResult) []AgentResult {
+	if s.config == nil || s.config.MaxSynthesisInputTokens <= 0 {
+		return inputs
+	}
+
+	count := s.config.TokenCounter
+	if count == nil {
+		count = ApproximateTokenCounter
+	}
+	total := 0
+	for _, input := range inputs {
+		total += count(input.Output)
+	}
+
+	similarity := s.similarity()
+	for total > s.config.MaxSynthesisInputTokens && len(inputs) > 1 {
+		drop := len(inputs) - 1
+		switch s.config.SynthesisDropPolicy {
+		case DropMostRedundant:
+			best := -1.0
+			for i, input := range inputs {
+				score := 0.0
+				for j, other := range inputs {
+					if i != j {
+						score += similarity(input.Output, other.Output)
+					}
+				}
+				if score > best {
+					best, drop = score, i
+				}
+			}
+		case DropLongest:
+			longest := -1
+			for i, input := range inputs {
+				if tokens := count(input.Output); tokens > longest {
+					longest, drop = tokens, i
+				}
+			}
+		}
+
+		total -= count(inputs[drop].Output)
+		s.droppedAgents = append(s.droppedAgents, inputs[drop].AgentID)
+		inputs = append(inputs[:drop:drop], inputs[drop+1:]...)
+	}
+	return inputs
+}
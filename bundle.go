@@ -0,0 +1,103 @@
+package societyai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// BundleVersion est la version actuelle du format des bundles exportés
+const BundleVersion = 1
+
+// Bundle est la représentation autodescriptive d'une exécution, destinée à être jointe
+// à un rapport de bug pour reproduire les parties non liées aux modèles.
+// Les modèles (et donc leurs clés d'API) ne sont jamais inclus.
+type Bundle struct {
+	Version     int                `json:"version"`
+	Mode        Mode               `json:"mode"`
+	Config      *Config            `json:"config"`
+	Assignments []BundleAssignment `json:"assignments"`
+	Result      *SocietyResult     `json:"result"`
+}
+
+// BundleAssignment décrit le modèle et la tâche attribués à un agent
+type BundleAssignment struct {
+	AgentID   int    `json:"agent_id"`
+	ModelName string `json:"model_name"`
+	Prompt    string `json:"prompt"`
+	Dimension string `json:"dimension,omitempty"`
+}
+
+// ExportBundle sérialise la configuration et le résultat d'une exécution en JSON.
+// Les champs non sérialisables de la configuration (modèles, ordonnanceur, intégrateur...)
+// sont exclus, de sorte qu'aucune clé d'API n'apparaît dans le bundle.
+func ExportBundle(config *Config, result *SocietyResult) ([]byte, error) {
+	if config == nil || result == nil {
+		return nil, errors.New("la configuration et le résultat sont requis pour exporter un bundle")
+	}
+
+	bundle := Bundle{
+		Version:     BundleVersion,
+		Mode:        result.Mode,
+		Config:      config,
+		Assignments: make([]BundleAssignment, len(result.Agents)),
+		Result:      result,
+	}
+	for i, agent := range result.Agents {
+		bundle.Assignments[i] = BundleAssignment{
+			AgentID:   agent.AgentID,
+			ModelName: agent.ModelName,
+			Prompt:    agent.Prompt,
+			Dimension: agent.Dimension,
+		}
+	}
+
+	return json.MarshalIndent(bundle, "", "  ")
+}
+
+// ImportBundle relit un bundle produit par ExportBundle
+func ImportBundle(data []byte) (*Config, *SocietyResult, error) {
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, nil, err
+	}
+
+	if bundle.Version != BundleVersion {
+		return nil, nil, fmt.Errorf("version de bundle non supportée: %d", bundle.Version)
+	}
+
+	return bundle.Config, bundle.Result, nil
+}
+
+// agentResultJSON est la forme sérialisée d'un AgentResult, l'erreur étant rendue par son message
+type agentResultJSON struct {
+	agentResultAlias
+	Err string `json:"Err,omitempty"`
+}
+
+// agentResultAlias évite la récursion de MarshalJSON lors de la sérialisation
+type agentResultAlias AgentResult
+
+// MarshalJSON sérialise le résultat en rendant l'erreur éventuelle par son message
+func (r AgentResult) MarshalJSON() ([]byte, error) {
+	aux := agentResultJSON{agentResultAlias: agentResultAlias(r)}
+	if r.Err != nil {
+		aux.Err = r.Err.Error()
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON relit un résultat sérialisé par MarshalJSON
+func (r *AgentResult) UnmarshalJSON(data []byte) error {
+	var aux agentResultJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*r = AgentResult(aux.agentResultAlias)
+	r.Err = nil
+	if aux.Err != "" {
+		r.Err = errors.New(aux.Err)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package societyai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultCache conserve les résultats finaux des exécutions pendant une durée limitée, pour que
+// les exécutions identiques (Config.Cache) soient servies sans appel aux modèles.
+// La clé combine le mode, le prompt, l'ensemble des modèles et une empreinte de la configuration :
+// une clé limitée au prompt servirait à tort un résultat obtenu avec une autre configuration,
+// en particulier en mode collaboratif où chaque option modifie le déroulement des phases.
+// Les champs de Config exclus de l'export JSON (modèles, fonctions, canaux...) n'entrent pas
+// dans l'empreinte ; seuls les noms des modèles de la société et du modèle de synthèse y figurent.
+// Le cache conserve au plus DefaultResultCacheMaxEntries entrées, sauf WithResultCacheMaxEntries.
+type ResultCache struct {
+	ttl        time.Duration
+	maxEntries int
+	mu         sync.Mutex
+	entries    map[string]cacheEntry
+	stored     uint64 // Nombre d'enregistrements, pour ordonner les entrées
+	hits       int64
+	misses     int64
+}
+
+// DefaultResultCacheMaxEntries est le nombre maximal d'entrées d'un ResultCache par défaut
+const DefaultResultCacheMaxEntries = 1024
+
+// ResultCacheOption règle un cache créé par NewResultCache
+type ResultCacheOption func(*ResultCache)
+
+// WithResultCacheMaxEntries limite le nombre d'entrées conservées : au-delà, les entrées expirées
+// puis les plus anciennes sont oubliées (illimité si max <= 0)
+func WithResultCacheMaxEntries(max int) ResultCacheOption {
+	return func(c *ResultCache) {
+		c.maxEntries = max
+	}
+}
+
+// cacheEntry est une entrée du cache avec son prompt, pour Purge, son rang d'enregistrement et son échéance
+type cacheEntry struct {
+	prompt    string
+	result    *SocietyResult
+	seq       uint64
+	expiresAt time.Time
+}
+
+// CacheStats rapporte l'activité d'un ResultCache
+type CacheStats struct {
+	Hits    int64 // Exécutions servies depuis le cache
+	Misses  int64 // Exécutions absentes ou expirées du cache
+	Entries int   // Entrées actuellement conservées, expirées comprises
+}
+
+// NewResultCache crée un cache dont les entrées expirent après ttl (jamais si ttl <= 0)
+func NewResultCache(ttl time.Duration, opts ...ResultCacheOption) *ResultCache {
+	c := &ResultCache{
+		ttl:        ttl,
+		maxEntries: DefaultResultCacheMaxEntries,
+		entries:    make(map[string]cacheEntry),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// get retourne une copie du résultat associé à key s'il n'a pas expiré
+func (c *ResultCache) get(key string) (*SocietyResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	return cloneSocietyResult(entry.result), true
+}
+
+// set enregistre une copie du résultat sous key. Lorsque le cache est plein, les entrées expirées
+// sont supprimées, puis les plus anciennes jusqu'à libérer une place.
+func (c *ResultCache) set(key, prompt string, result *SocietyResult) {
+	now := time.Now()
+	entry := cacheEntry{prompt: prompt, result: cloneSocietyResult(result)}
+	if c.ttl > 0 {
+		entry.expiresAt = now.Add(c.ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if !e.expiresAt.IsZero() && now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		for len(c.entries) >= c.maxEntries {
+			c.evictOldest()
+		}
+	}
+	c.stored++
+	entry.seq = c.stored
+	c.entries[key] = entry
+}
+
+// evictOldest supprime l'entrée enregistrée la première (appelé verrou détenu)
+func (c *ResultCache) evictOldest() {
+	var oldest string
+	var oldestSeq uint64
+	for key, entry := range c.entries {
+		if oldestSeq == 0 || entry.seq < oldestSeq {
+			oldest, oldestSeq = key, entry.seq
+		}
+	}
+	delete(c.entries, oldest)
+}
+
+// cloneSocietyResult copie le résultat ainsi que ses listes, tables et détails (configuration résolue,
+// accord entre modèles, phases collaboratives), pour que le cache et l'appelant ne partagent aucune donnée
+func cloneSocietyResult(result *SocietyResult) *SocietyResult {
+	clone := *result
+	clone.Agents = append([]AgentResult(nil), result.Agents...)
+	clone.SynthesisCandidates = append([]string(nil), result.SynthesisCandidates...)
+	clone.Scratchpad = append([]string(nil), result.Scratchpad...)
+	clone.DroppedAgents = append([]int(nil), result.DroppedAgents...)
+	clone.UnsupportedStatements = append([]string(nil), result.UnsupportedStatements...)
+	clone.Tags = cloneTags(result.Tags)
+	if result.Collaborative != nil {
+		collaborative := *result.Collaborative
+		collaborative.Dimensions = append([]string(nil), collaborative.Dimensions...)
+		collaborative.Insights = append([]string(nil), collaborative.Insights...)
+		collaborative.FinishReasons = append([]string(nil), collaborative.FinishReasons...)
+		collaborative.PhaseDurations = cloneDurations(collaborative.PhaseDurations)
+		if collaborative.Routing != nil {
+			collaborative.Routing = make([]RoutingDecision, len(result.Collaborative.Routing))
+			for i, decision := range result.Collaborative.Routing {
+				decision.Matched = append([]string(nil), decision.Matched...)
+				collaborative.Routing[i] = decision
+			}
+		}
+		clone.Collaborative = &collaborative
+	}
+	if result.Agreement != nil {
+		agreement := *result.Agreement
+		agreement.Models = append([]string(nil), agreement.Models...)
+		agreement.Matrix = make([][]float64, len(result.Agreement.Matrix))
+		for i, row := range result.Agreement.Matrix {
+			agreement.Matrix[i] = append([]float64(nil), row...)
+		}
+		clone.Agreement = &agreement
+	}
+	if result.ResolvedConfig != nil {
+		clone.ResolvedConfig = cloneConfig(result.ResolvedConfig)
+	}
+	return &clone
+}
+
+// cloneConfig copie la configuration et ses listes et tables ; les extensions (modèles, fonctions,
+// caches, ordonnanceurs...) restent partagées
+func cloneConfig(config *Config) *Config {
+	clone := *config
+	clone.Tags = cloneTags(config.Tags)
+	clone.DimensionTimeouts = cloneDurations(config.DimensionTimeouts)
+	clone.Dimensions = append([]string(nil), config.Dimensions...)
+	clone.Perspectives = append([]string(nil), config.Perspectives...)
+	clone.AgentWeights = append([]float64(nil), config.AgentWeights...)
+	clone.AgentMaxWordsByAgent = append([]int(nil), config.AgentMaxWordsByAgent...)
+	clone.ScaffoldingPhrases = append([]string(nil), config.ScaffoldingPhrases...)
+	return &clone
+}
+
+// cloneTags copie une table d'étiquettes (nil pour une table nil)
+func cloneTags(tags map[string]string) map[string]string {
+	if tags == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(tags))
+	for key, value := range tags {
+		clone[key] = value
+	}
+	return clone
+}
+
+// cloneDurations copie une table de durées (nil pour une table nil)
+func cloneDurations(durations map[string]time.Duration) map[string]time.Duration {
+	if durations == nil {
+		return nil
+	}
+	clone := make(map[string]time.Duration, len(durations))
+	for key, value := range durations {
+		clone[key] = value
+	}
+	return clone
+}
+
+// Purge supprime toutes les entrées obtenues pour ce prompt, quels que soient le mode,
+// les modèles et la configuration
+func (c *ResultCache) Purge(prompt string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.prompt == prompt {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Stats retourne le nombre de succès et d'échecs du cache et le nombre d'entrées conservées
+func (c *ResultCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Entries: len(c.entries)}
+}
+
+// cacheKey construit la clé du cache pour une exécution : mode, prompt, modèles et empreinte
+// de la configuration. Une configuration impossible à sérialiser n'est pas mise en cache.
+func cacheKey(mode Mode, config *Config, models []AIModel) (string, bool) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", false
+	}
+
+	names := make([]string, 0, len(models)+1)
+	for _, model := range models {
+		names = append(names, model.Name())
+	}
+	if mode == ModeSynthesis && config.SynthesisModel != nil {
+		names = append(names, "synthesis:"+config.SynthesisModel.Name())
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(mode))
+	hash.Write([]byte{0})
+	hash.Write([]byte(config.Prompt))
+	hash.Write([]byte{0})
+	hash.Write([]byte(strings.Join(names, "\x00")))
+	hash.Write([]byte{0})
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// recordCache transmet un succès ou un échec du cache à Config.Metrics
+func recordCache(config *Config, mode Mode, hit bool) {
+	if config.Metrics == nil {
+		return
+	}
+	tags := make(map[string]string, len(config.Tags)+1)
+	for key, value := range config.Tags {
+		tags[key] = value
+	}
+	tags["mode"] = string(mode)
+
+	name := MetricCacheMisses
+	if hit {
+		name = MetricCacheHits
+	}
+	config.Metrics.IncCounter(name, 1, tags)
+}
@@ -0,0 +1,121 @@
+package societyai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/benoitpetit/societyai"
+	"github.com/benoitpetit/societyai/testmodel"
+)
+
+func TestResultCacheEvictsBeyondMaxEntries(t *testing.T) {
+	cache := societyai.NewResultCache(0, societyai.WithResultCacheMaxEntries(2))
+	model := testmodel.New("model", nil)
+
+	for _, prompt := range []string{"Question 1", "Question 2", "Question 3"} {
+		config := societyai.NewConfig(prompt, 1)
+		config.Cache = cache
+		if _, err := societyai.RunDetailed(context.Background(), societyai.ModeStandard, config, []societyai.AIModel{model}); err != nil {
+			t.Fatalf("exécution en échec: %v", err)
+		}
+	}
+	if entries := cache.Stats().Entries; entries != 2 {
+		t.Errorf("%d entrée(s) conservée(s), 2 attendues", entries)
+	}
+
+	// La plus ancienne exécution a été oubliée, la plus récente est servie depuis le cache
+	model.Reset()
+	for _, prompt := range []string{"Question 3", "Question 1"} {
+		config := societyai.NewConfig(prompt, 1)
+		config.Cache = cache
+		if _, err := societyai.RunDetailed(context.Background(), societyai.ModeStandard, config, []societyai.AIModel{model}); err != nil {
+			t.Fatalf("exécution en échec: %v", err)
+		}
+	}
+	if calls := model.Calls(); calls != 1 {
+		t.Errorf("%d appel(s) au modèle, seule la question oubliée devait être relancée", calls)
+	}
+}
+
+func TestResultCacheDoesNotShareAgents(t *testing.T) {
+	cache := societyai.NewResultCache(0)
+	model := testmodel.New("model", func(prompt string) (string, error) {
+		return "réponse d'origine", nil
+	})
+	run := func() *societyai.SocietyResult {
+		config := societyai.NewConfig("Quel outil de CI choisir ?", 2)
+		config.Cache = cache
+		config.Tags = map[string]string{"équipe": "plateforme"}
+		config.ComputeAgreementStats = true
+		result, err := societyai.RunDetailed(context.Background(), societyai.ModeStandard, config, []societyai.AIModel{model})
+		if err != nil {
+			t.Fatalf("exécution en échec: %v", err)
+		}
+		return result
+	}
+
+	first := run()
+	first.Agents[0].Output = "modifiée par l'appelant"
+	first.ResolvedConfig.Tags["équipe"] = "modifiée par l'appelant"
+	first.Agreement.Models[0] = "modifié par l'appelant"
+	first.Agreement.Matrix[0][0] = -1
+	second := run()
+	second.Agents[1].Output = "modifiée par l'appelant"
+
+	third := run()
+	for i, agent := range third.Agents {
+		if agent.Output != "réponse d'origine" {
+			t.Errorf("Agents[%d].Output = %q, le résultat en cache a été modifié", i, agent.Output)
+		}
+	}
+	if tag := third.ResolvedConfig.Tags["équipe"]; tag != "plateforme" {
+		t.Errorf("ResolvedConfig.Tags[équipe] = %q, la configuration en cache a été modifiée", tag)
+	}
+	if third.Agreement.Models[0] != "model" || third.Agreement.Matrix[0][0] == -1 {
+		t.Errorf("Agreement = %+v, les statistiques en cache ont été modifiées", third.Agreement)
+	}
+	if calls := model.Calls(); calls != 2 {
+		t.Errorf("%d appel(s) au modèle, seule la première exécution devait l'appeler", calls)
+	}
+}
+
+// capableModel déclare des capacités pour l'attribution des dimensions (Config.SmartRouting)
+type capableModel struct {
+	*testmodel.Model
+	capabilities []string
+}
+
+func (m capableModel) Capabilities() []string {
+	return m.capabilities
+}
+
+func TestResultCacheDoesNotShareRouting(t *testing.T) {
+	cache := societyai.NewResultCache(0)
+	models := []societyai.AIModel{
+		testmodel.New("généraliste", nil),
+		capableModel{Model: testmodel.New("praticien", nil), capabilities: []string{"pratiques"}},
+	}
+	run := func() *societyai.SocietyResult {
+		config := societyai.NewConfig("Comment déployer un service ?", 2)
+		config.Cache = cache
+		config.SmartRouting = true
+		result, err := societyai.RunDetailed(context.Background(), societyai.ModeCollaborative, config, models)
+		if err != nil {
+			t.Fatalf("exécution en échec: %v", err)
+		}
+		return result
+	}
+
+	first := run()
+	routing := first.Collaborative.Routing
+	if len(routing) < 2 || len(routing[1].Matched) == 0 {
+		t.Fatalf("attribution inattendue: %+v", routing)
+	}
+	routing[1].Matched[0] = "modifiée par l'appelant"
+	routing[0].ModelName = "modifié par l'appelant"
+
+	second := run().Collaborative.Routing
+	if second[1].Matched[0] != "pratiques" || second[0].ModelName == "modifié par l'appelant" {
+		t.Errorf("Routing = %+v, l'attribution en cache a été modifiée", second)
+	}
+}
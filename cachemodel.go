@@ -0,0 +1,136 @@
+package societyai
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheModelOption règle un modèle créé par NewCacheModel
+type CacheModelOption func(*cacheModel)
+
+// WithCacheTTL fait expirer les réponses mémorisées après ttl (jamais si ttl <= 0, par défaut)
+func WithCacheTTL(ttl time.Duration) CacheModelOption {
+	return func(m *cacheModel) {
+		m.ttl = ttl
+	}
+}
+
+// WithCacheMaxEntries limite le nombre de réponses mémorisées : au-delà, la réponse utilisée
+// le moins récemment est oubliée (illimité si max <= 0, par défaut)
+func WithCacheMaxEntries(max int) CacheModelOption {
+	return func(m *cacheModel) {
+		m.maxEntries = max
+	}
+}
+
+// cacheModel mémorise les réponses du modèle enveloppé
+type cacheModel struct {
+	inner      AIModel
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[cacheModelKey]*list.Element
+	order   *list.List // Entrées de la plus récemment utilisée à la plus ancienne
+}
+
+// cacheModelKey identifie un appel : le prompt exact et les paramètres de génération
+type cacheModelKey struct {
+	prompt string
+	opts   ProcessOptions
+}
+
+// cacheModelEntry est une réponse mémorisée avec son échéance
+type cacheModelEntry struct {
+	key       cacheModelKey
+	response  string
+	expiresAt time.Time
+}
+
+// NewCacheModel enveloppe inner pour mémoriser ses réponses, indexées par le prompt exact
+// et les paramètres de génération : relancer une société avec les mêmes entrées sert les
+// réponses des agents sans appel au fournisseur. Seules les réponses réussies sont mémorisées.
+// Le modèle peut être partagé par des agents exécutés en parallèle ; deux appels simultanés
+// du même prompt absent du cache sont toutefois tous deux transmis à inner.
+func NewCacheModel(inner AIModel, opts ...CacheModelOption) AIModel {
+	m := &cacheModel{
+		inner:   inner,
+		entries: make(map[cacheModelKey]*list.Element),
+		order:   list.New(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Name implémente l'interface AIModel
+func (m *cacheModel) Name() string {
+	return m.inner.Name()
+}
+
+// Process implémente l'interface AIModel
+func (m *cacheModel) Process(ctx context.Context, prompt string) (string, error) {
+	return m.ProcessWithOptions(ctx, prompt, ProcessOptions{})
+}
+
+// ProcessWithOptions implémente l'interface ConfigurableAIModel
+func (m *cacheModel) ProcessWithOptions(ctx context.Context, prompt string, opts ProcessOptions) (string, error) {
+	key := cacheModelKey{prompt: prompt, opts: opts}
+	if response, ok := m.get(key); ok {
+		return response, nil
+	}
+
+	response, err := processWithOptions(ctx, m.inner, prompt, opts)
+	if err != nil {
+		return "", err
+	}
+	m.set(key, response)
+	return response, nil
+}
+
+// get retourne la réponse mémorisée pour key si elle n'a pas expiré
+func (m *cacheModel) get(key cacheModelKey) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return "", false
+	}
+	entry := element.Value.(*cacheModelEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		m.order.Remove(element)
+		delete(m.entries, key)
+		return "", false
+	}
+	m.order.MoveToFront(element)
+	return entry.response, true
+}
+
+// set mémorise la réponse pour key, en oubliant les réponses les moins récemment utilisées
+// au-delà du nombre maximal d'entrées
+func (m *cacheModel) set(key cacheModelKey, response string) {
+	entry := &cacheModelEntry{key: key, response: response}
+	if m.ttl > 0 {
+		entry.expiresAt = time.Now().Add(m.ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+	} else {
+		m.entries[key] = m.order.PushFront(entry)
+	}
+
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*cacheModelEntry).key)
+	}
+}
@@ -0,0 +1,189 @@
+package societyai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Checkpointer conserve l'état d'une exécution en plusieurs tours entre deux tours (Config.Checkpointer).
+// Les états sont des documents JSON opaques, indexés par une clé propre à chaque exécution.
+type Checkpointer interface {
+	// Save enregistre l'état sous key, en remplaçant l'état précédent
+	Save(ctx context.Context, key string, state []byte) error
+	// Load retourne l'état enregistré sous key, ou ok à false s'il n'y en a aucun
+	Load(ctx context.Context, key string) (state []byte, ok bool, err error)
+	// Delete supprime l'état enregistré sous key, s'il existe
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryCheckpointer conserve les états en mémoire : la reprise n'est possible qu'au sein du même processus
+type MemoryCheckpointer struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+// NewMemoryCheckpointer crée un Checkpointer en mémoire
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{states: make(map[string][]byte)}
+}
+
+// Save implémente l'interface Checkpointer
+func (c *MemoryCheckpointer) Save(ctx context.Context, key string, state []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.states[key] = append([]byte(nil), state...)
+	return nil
+}
+
+// Load implémente l'interface Checkpointer
+func (c *MemoryCheckpointer) Load(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state, ok := c.states[key]
+	return append([]byte(nil), state...), ok, nil
+}
+
+// Delete implémente l'interface Checkpointer
+func (c *MemoryCheckpointer) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.states, key)
+	return nil
+}
+
+// FileCheckpointer enregistre chaque état dans un fichier du répertoire Dir, qui doit exister :
+// l'exécution reprend même après l'arrêt brutal du processus. Chaque état est écrit dans un fichier
+// temporaire puis renommé, de sorte qu'une interruption en cours d'écriture conserve l'état précédent.
+type FileCheckpointer struct {
+	Dir string
+}
+
+// path retourne le fichier de l'état key, nommé d'après une empreinte de la clé
+func (c FileCheckpointer) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, "societyai-"+hex.EncodeToString(sum[:])+".json")
+}
+
+// Save implémente l'interface Checkpointer
+func (c FileCheckpointer) Save(ctx context.Context, key string, state []byte) error {
+	tmp, err := os.CreateTemp(c.Dir, "societyai-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(state); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Load implémente l'interface Checkpointer
+func (c FileCheckpointer) Load(ctx context.Context, key string) ([]byte, bool, error) {
+	state, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+// Delete implémente l'interface Checkpointer
+func (c FileCheckpointer) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(c.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// roundState est l'état enregistré après chaque tour terminé d'une exécution en plusieurs tours
+type roundState struct {
+	Round   int             // Nombre de tours terminés
+	Agents  []AgentResult   // Résultats des agents au dernier tour terminé, erreurs réduites à leur message
+	Prompts []string        `json:",omitempty"` // Prompts des agents au premier tour (passes)
+	Rounds  [][]AgentOutput `json:",omitempty"` // Sorties des agents à chaque tour terminé (débat)
+}
+
+// resumable indique si l'état permet de reprendre une exécution de rounds tours menée par agents agents
+func (st *roundState) resumable(agents, rounds int) bool {
+	return st != nil && st.Round >= 1 && st.Round <= rounds && len(st.Agents) == agents
+}
+
+// checkpointKey retourne la clé de l'état de l'exécution auprès de Config.Checkpointer, vide lorsque
+// les états ne sont pas enregistrés ; kind distingue les boucles de tours (passes, débat)
+func (s *SocietyGroup) checkpointKey(kind string) string {
+	if s.config == nil || s.config.Checkpointer == nil {
+		return ""
+	}
+	if s.config.CheckpointKey != "" {
+		return kind + ":" + s.config.CheckpointKey
+	}
+	key, ok := cacheKey(Mode(kind), s.config, s.Models)
+	if !ok {
+		return ""
+	}
+	return kind + ":" + key
+}
+
+// loadCheckpoint retourne l'état enregistré sous key, nil s'il n'y en a aucun
+func (s *SocietyGroup) loadCheckpoint(ctx context.Context, key string) (*roundState, error) {
+	if key == "" {
+		return nil, nil
+	}
+	data, ok, err := s.config.Checkpointer.Load(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("lecture de l'état enregistré: %w", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+	var state roundState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("état enregistré illisible: %w", err)
+	}
+	return &state, nil
+}
+
+// saveCheckpoint enregistre l'état sous key, complété des résultats actuels des agents
+func (s *SocietyGroup) saveCheckpoint(ctx context.Context, key string, state *roundState) error {
+	if key == "" {
+		return nil
+	}
+	state.Agents = s.agentResults
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := s.config.Checkpointer.Save(ctx, key, data); err != nil {
+		return fmt.Errorf("enregistrement du tour %d: %w", state.Round, err)
+	}
+	return nil
+}
+
+// clearCheckpoint supprime l'état enregistré sous key, une fois tous les tours terminés
+func (s *SocietyGroup) clearCheckpoint(ctx context.Context, key string) error {
+	if key == "" {
+		return nil
+	}
+	return s.config.Checkpointer.Delete(ctx, key)
+}
+
+// restoreCheckpoint rétablit les résultats des agents enregistrés ; les prompts des agents
+// sont réputés déjà préparés (décomposition, reformulation)
+func (s *SocietyGroup) restoreCheckpoint(state *roundState) {
+	s.prepared = true
+	s.agentResults = state.Agents
+}
@@ -0,0 +1,131 @@
+package societyai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/benoitpetit/societyai"
+	"github.com/benoitpetit/societyai/testmodel"
+)
+
+// roundModel répond [v1] au premier tour puis [vN+1] à partir des réponses [vN] du tour précédent,
+// et échoue au tour failRound tant que failRound est non nul
+func roundModel(failRound *atomic.Int64) *testmodel.Model {
+	errCrash := errors.New("processus interrompu")
+	return testmodel.New("model", func(prompt string) (string, error) {
+		round := 1
+		for n := 9; n >= 1; n-- {
+			if strings.Contains(prompt, fmt.Sprintf("[v%d]", n)) {
+				round = n + 1
+				break
+			}
+		}
+		if int64(round) == failRound.Load() {
+			return "", errCrash
+		}
+		return fmt.Sprintf("[v%d]", round), nil
+	})
+}
+
+func TestPassesResumeFromCheckpoint(t *testing.T) {
+	var failRound atomic.Int64
+	failRound.Store(3)
+	model := roundModel(&failRound)
+	checkpointer := societyai.NewMemoryCheckpointer()
+
+	newConfig := func() *societyai.Config {
+		config := societyai.NewConfig("Comment réduire la latence ?", 2)
+		config.Passes = 3
+		config.Checkpointer = checkpointer
+		return config
+	}
+
+	if _, err := societyai.RunSocietyDetailed(context.Background(), newConfig(), []societyai.AIModel{model}); err == nil {
+		t.Fatal("la troisième passe devait échouer")
+	}
+
+	failRound.Store(0)
+	model.Reset()
+	result, err := societyai.RunSocietyDetailed(context.Background(), newConfig(), []societyai.AIModel{model})
+	if err != nil {
+		t.Fatalf("reprise en échec: %v", err)
+	}
+	if calls := model.Calls(); calls != 2 {
+		t.Errorf("%d appel(s) à la reprise, seule la troisième passe (2 appels) devait être relancée", calls)
+	}
+	for i, agent := range result.Agents {
+		if agent.Output != "[v3]" {
+			t.Errorf("Agents[%d].Output = %q, attendu la réponse de la troisième passe", i, agent.Output)
+		}
+	}
+
+	// L'état est supprimé une fois toutes les passes terminées
+	model.Reset()
+	if _, err := societyai.RunSocietyDetailed(context.Background(), newConfig(), []societyai.AIModel{model}); err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+	if calls := model.Calls(); calls != 6 {
+		t.Errorf("%d appel(s) après une exécution complète, 6 attendus", calls)
+	}
+}
+
+func TestDebateResumeFromCheckpoint(t *testing.T) {
+	var failRound atomic.Int64
+	failRound.Store(3)
+	model := roundModel(&failRound)
+
+	config := societyai.NewConfig("Faut-il imposer la revue de code ?", 2)
+	config.Checkpointer = societyai.FileCheckpointer{Dir: t.TempDir()}
+
+	if _, err := societyai.RunSocietyDebate(context.Background(), config, []societyai.AIModel{model}, 3); err == nil {
+		t.Fatal("le troisième tour devait échouer")
+	}
+
+	failRound.Store(0)
+	model.Reset()
+	result, err := societyai.RunSocietyDebate(context.Background(), config, []societyai.AIModel{model}, 3)
+	if err != nil {
+		t.Fatalf("reprise en échec: %v", err)
+	}
+	// Le troisième tour puis le consensus
+	if calls := model.Calls(); calls != 3 {
+		t.Errorf("%d appel(s) à la reprise, 3 attendus", calls)
+	}
+	if len(result.Rounds) != 3 {
+		t.Fatalf("%d tour(s) dans le résultat, 3 attendus", len(result.Rounds))
+	}
+	for round, outputs := range result.Rounds {
+		want := fmt.Sprintf("[v%d]", round+1)
+		for _, output := range outputs {
+			if output.Output != want {
+				t.Errorf("tour %d, agent %d: %q, attendu %q", round+1, output.AgentID, output.Output, want)
+			}
+		}
+	}
+}
+
+func TestFileCheckpointer(t *testing.T) {
+	ctx := context.Background()
+	checkpointer := societyai.FileCheckpointer{Dir: t.TempDir()}
+
+	if _, ok, err := checkpointer.Load(ctx, "clé/avec:séparateurs"); ok || err != nil {
+		t.Fatalf("Load d'une clé absente: ok=%v, err=%v", ok, err)
+	}
+	if err := checkpointer.Save(ctx, "clé/avec:séparateurs", []byte(`{"Round":1}`)); err != nil {
+		t.Fatalf("Save en échec: %v", err)
+	}
+	state, ok, err := checkpointer.Load(ctx, "clé/avec:séparateurs")
+	if err != nil || !ok || string(state) != `{"Round":1}` {
+		t.Fatalf("Load = %q, %v, %v", state, ok, err)
+	}
+	if err := checkpointer.Delete(ctx, "clé/avec:séparateurs"); err != nil {
+		t.Fatalf("Delete en échec: %v", err)
+	}
+	if _, ok, _ := checkpointer.Load(ctx, "clé/avec:séparateurs"); ok {
+		t.Error("l'état devrait être supprimé")
+	}
+}
@@ -0,0 +1,70 @@
+package societyai_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benoitpetit/societyai"
+	"github.com/benoitpetit/societyai/testmodel"
+)
+
+// À exécuter avec go test -race : les agents d'exploration partagent le même modèle
+func TestCollaborativeDimensionAgentError(t *testing.T) {
+	failing := societyai.DefaultDimensions[1]
+	errModel := errors.New("modèle indisponible")
+	model := testmodel.New("model", func(prompt string) (string, error) {
+		if strings.Contains(prompt, failing) {
+			return "", errModel
+		}
+		return "analyse", nil
+	})
+
+	config := societyai.NewConfig("Comment réduire la dette technique ?", 3)
+	_, err := societyai.RunSocietyCollaborativeDetailed(context.Background(), config, []societyai.AIModel{model})
+	if !errors.Is(err, errModel) {
+		t.Fatalf("erreur attendue %v, obtenu %v", errModel, err)
+	}
+
+	var agentErr *societyai.AgentError
+	if !errors.As(err, &agentErr) {
+		t.Fatalf("AgentError attendue, obtenu %T", err)
+	}
+	if agentErr.Dimension != failing {
+		t.Errorf("dimension en échec %q, attendu %q", agentErr.Dimension, failing)
+	}
+}
+
+func TestCollaborativeInsightsFollowDimensions(t *testing.T) {
+	dimensions := []string{"Coûts", "Sécurité", "Performance"}
+	model := testmodel.New("model", func(prompt string) (string, error) {
+		for i, dimension := range dimensions {
+			if strings.Contains(prompt, "dimension spécifique: "+dimension) {
+				// Le premier agent termine son exploration après les autres
+				if i == 0 {
+					time.Sleep(50 * time.Millisecond)
+				}
+				return "analyse de " + dimension, nil
+			}
+		}
+		return "réponse", nil
+	})
+
+	config := societyai.NewConfig("Faut-il adopter Kubernetes ?", len(dimensions))
+	config.Dimensions = dimensions
+	result, err := societyai.RunSocietyCollaborativeDetailed(context.Background(), config, []societyai.AIModel{model})
+	if err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+
+	if len(result.Insights) != len(result.Dimensions) {
+		t.Fatalf("%d analyse(s) pour %d dimension(s)", len(result.Insights), len(result.Dimensions))
+	}
+	for i, dimension := range result.Dimensions {
+		if want := "analyse de " + dimension; result.Insights[i] != want {
+			t.Errorf("Insights[%d] = %q, attendu %q", i, result.Insights[i], want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+)
+
+// PromptCompressor réduit un texte trop long pour qu'il tienne dans la fenêtre de contexte d'un modèle.
+// La société l'invoque avant l'envoi de tout prompt dépassant Config.MaxPromptChars.
+type PromptCompressor interface {
+	// Compress retourne une version du texte d'au plus targetChars caractères
+	Compress(ctx context.Context, text string, targetChars int) (string, error)
+}
+
+// ModelCompressor est le compresseur par défaut : il demande à un modèle de condenser le texte,
+// puis tronque le résultat si le modèle n'a pas respecté la limite
+type ModelCompressor struct {
+	Model AIModel
+}
+
+// Compress implémente l'interface PromptCompressor
+func (c ModelCompressor) Compress(ctx context.Context, text string, targetChars int) (string, error) {
+	if c.Model == nil {
+		return "", ErrNoModelsSpecified
+	}
+
+	prompt := fmt.Sprintf(
+		"Condense le texte suivant en au plus %d caractères. Conserve les consignes, la question posée "+
+			"et toutes les informations essentielles ; supprime les redondances. "+
+			"Retourne uniquement le texte condensé:\n\n%s",
+		targetChars,
+		text,
+	)
+
+	compressed, err := c.Model.Process(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	return truncateRunes(compressed, targetChars), nil
+}
+
+// truncateRunes tronque un texte à max caractères
+func truncateRunes(text string, max int) string {
+	runes := []rune(text)
+	if max <= 0 || len(runes) <= max {
+		return text
+	}
+	return string(runes[:max])
+}
+
+// compressPrompt compresse le prompt lorsqu'il dépasse Config.MaxPromptChars
+func (s *SocietyGroup) compressPrompt(ctx context.Context, model AIModel, prompt string) (string, error) {
+	if s.config == nil || s.config.MaxPromptChars <= 0 || len([]rune(prompt)) <= s.config.MaxPromptChars {
+		return prompt, nil
+	}
+
+	compressor := s.config.PromptCompressor
+	if compressor == nil {
+		// Le modèle destinataire condense lui-même le prompt, sans nouvelle compression
+		compressor = ModelCompressor{Model: s.directModel(model)}
+	}
+
+	compressed, err := compressor.Compress(ctx, prompt, s.config.MaxPromptChars)
+	if err != nil {
+		return "", fmt.Errorf("échec de la compression du prompt: %w", err)
+	}
+	return compressed, nil
+}
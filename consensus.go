@@ -0,0 +1,91 @@
+package societyai
+
+import "context"
+
+// AgentScore est le score de consensus d'un agent : la similarité moyenne de sa réponse
+// avec celles des autres agents
+type AgentScore struct {
+	AgentID int
+	Score   float64
+}
+
+// ConsensusResult contient la réponse retenue par consensus et l'agent qui l'a produite.
+// Agréger WinnerPerspective et WinnerModel sur de nombreuses exécutions indique quelles
+// perspectives et quels modèles produisent le plus souvent la meilleure réponse.
+type ConsensusResult struct {
+	Answer            string       // Réponse retenue
+	WinnerAgentID     int          // Agent ayant produit la réponse retenue
+	WinnerModel       string       // Modèle de l'agent gagnant
+	WinnerPerspective string       // Perspective (ou dimension) de l'agent gagnant
+	Scores            []AgentScore // Score de chaque agent ayant répondu, dans l'ordre des agents
+	Result            *SocietyResult
+}
+
+// RunSocietyConsensus exécute les agents comme en mode standard puis retient, par vote implicite,
+// la réponse la plus proche de l'ensemble des autres (similarité moyenne maximale, Config.Similarity
+// ou JaccardSimilarity par défaut) plutôt que de juxtaposer ou synthétiser les perspectives
+func RunSocietyConsensus(ctx context.Context, config *Config, models []AIModel) (*ConsensusResult, error) {
+	result, err := RunSocietyDetailed(ctx, config, models)
+	if err != nil {
+		return nil, err
+	}
+
+	similarity := config.Similarity
+	if similarity == nil {
+		similarity = JaccardSimilarity
+	}
+	consensus := selectConsensus(result.Agents, similarity)
+	if consensus == nil {
+		return nil, ErrProcessingFailed
+	}
+	consensus.Result = result
+	return consensus, nil
+}
+
+// selectConsensus retient la réponse dont la similarité moyenne avec les autres est la plus élevée ;
+// en cas d'égalité, l'agent de plus petit identifiant l'emporte
+func selectConsensus(results []AgentResult, similarity Similarity) *ConsensusResult {
+	var active []AgentResult
+	for _, result := range results {
+		if !result.Skipped && result.Err == nil {
+			active = append(active, result)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+
+	consensus := &ConsensusResult{Scores: make([]AgentScore, len(active))}
+	best := -1.0
+	for i, result := range active {
+		score := 1.0
+		if len(active) > 1 {
+			total := 0.0
+			for j, other := range active {
+				if i != j {
+					total += similarity(result.Output, other.Output)
+				}
+			}
+			score = total / float64(len(active)-1)
+		}
+		consensus.Scores[i] = AgentScore{AgentID: result.AgentID, Score: score}
+
+		if score > best {
+			best = score
+			consensus.Answer = result.Output
+			consensus.WinnerAgentID = result.AgentID
+			consensus.WinnerModel = result.ModelName
+			consensus.WinnerPerspective = agentPerspective(result)
+		}
+	}
+	return consensus
+}
+
+// agentPerspective retourne la dimension explorée par l'agent en mode collaboratif,
+// ou la perspective qui lui a été attribuée en mode standard
+func agentPerspective(result AgentResult) string {
+	if result.Dimension != "" {
+		return result.Dimension
+	}
+	return result.Perspective
+}
@@ -0,0 +1,43 @@
+package societyai
+
+import "context"
+
+// ConversationTrimmer est une interface optionnelle pour les modèles qui conservent un historique
+// de conversation entre les appels. La société l'appelle au début de chaque exécution lorsque
+// Config.MaxConversationTurns est défini, afin que les modèles réutilisés ne voient pas leur
+// historique (et donc leur mémoire et leur coût) croître indéfiniment.
+type ConversationTrimmer interface {
+	// TrimTo ne conserve que les maxTurns derniers échanges de l'historique (aucun pour 0)
+	TrimTo(maxTurns int)
+}
+
+// StatelessWrapper rend sans état un modèle implémentant ConversationTrimmer : son historique
+// est vidé avant chaque appel, chaque prompt étant ainsi traité indépendamment des précédents.
+// Les modèles qui n'implémentent pas ConversationTrimmer sont appelés tels quels.
+type StatelessWrapper struct {
+	AIModel
+}
+
+// Process implémente l'interface AIModel
+func (w StatelessWrapper) Process(ctx context.Context, prompt string) (string, error) {
+	if trimmer, ok := w.AIModel.(ConversationTrimmer); ok {
+		trimmer.TrimTo(0)
+	}
+	return w.AIModel.Process(ctx, prompt)
+}
+
+// trimConversations limite l'historique des modèles de la société, y compris les modèles de synthèse
+// et de coordination, à Config.MaxConversationTurns échanges
+func (s *SocietyGroup) trimConversations() {
+	if s.config == nil || s.config.MaxConversationTurns <= 0 {
+		return
+	}
+
+	models := append([]AIModel(nil), s.Models...)
+	models = append(models, s.config.SynthesisModel, s.config.CoordinatorModel, s.synthModel)
+	for _, model := range models {
+		if trimmer, ok := model.(ConversationTrimmer); ok {
+			trimmer.TrimTo(s.config.MaxConversationTurns)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package societyai_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/benoitpetit/societyai"
+)
+
+// trimmedModel mémorise les limites d'historique demandées via TrimTo
+type trimmedModel struct {
+	mu      sync.Mutex
+	trimmed []int
+}
+
+func (m *trimmedModel) Name() string {
+	return "trimmed"
+}
+
+func (m *trimmedModel) Process(ctx context.Context, prompt string) (string, error) {
+	return "synthèse", nil
+}
+
+func (m *trimmedModel) TrimTo(maxTurns int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.trimmed = append(m.trimmed, maxTurns)
+}
+
+func TestTrimConversationsIncludesSynthesisModel(t *testing.T) {
+	agents := societyai.NewFuncModel("agents", func(ctx context.Context, prompt string) (string, error) {
+		return "réponse", nil
+	})
+	synth := &trimmedModel{}
+
+	config := societyai.NewConfig("Quel fournisseur cloud retenir ?", 2)
+	config.MaxConversationTurns = 3
+	if _, err := societyai.RunSocietyWithSynthesisDetailed(context.Background(), config, []societyai.AIModel{agents}, synth); err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+
+	if len(synth.trimmed) == 0 || synth.trimmed[0] != config.MaxConversationTurns {
+		t.Errorf("historique du modèle de synthèse limité à %v, %d attendu", synth.trimmed, config.MaxConversationTurns)
+	}
+}
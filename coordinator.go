@@ -0,0 +1,122 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// clarificationPattern reconnaît les demandes de précision du coordinateur (« QUESTION AGENT 2: ... »)
+var clarificationPattern = regexp.MustCompile(`(?i)^\W*question\s+(?:à\s+l'|to\s+)?agent\s+(\d+)\s*[:\-–—]\s*(.+)$`)
+
+// clarification est une question adressée par le coordinateur à un agent
+type clarification struct {
+	agent    AgentResult
+	question string
+	answer   string
+}
+
+// coordinatorModel retourne le modèle coordinateur du mode standard : Config.CoordinatorModel,
+// ou le dernier des modèles de la société avec Config.UseCoordinator ; nil sans coordination
+func (s *SocietyGroup) coordinatorModel() AIModel {
+	if s.config == nil {
+		return nil
+	}
+	if s.config.CoordinatorModel != nil {
+		return s.config.CoordinatorModel
+	}
+	if s.config.UseCoordinator && len(s.Models) > 0 {
+		return s.Models[len(s.Models)-1]
+	}
+	return nil
+}
+
+// coordinate fait produire une réponse coordonnée par le coordinateur à partir des réponses
+// des agents. Le coordinateur peut demander des précisions à certains agents lors d'un unique tour supplémentaire,
+// après lequel il doit rendre sa réponse définitive.
+func (s *SocietyGroup) coordinate(ctx context.Context, coordinator AIModel) (string, error) {
+	results := s.activeResults()
+
+	answer, err := s.callModel(ctx, coordinator, buildCoordinatorPrompt(s.config.userPrompt(), results, nil), ProcessOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	clarifications := parseClarifications(answer, results)
+	if len(clarifications) == 0 {
+		return answer, nil
+	}
+
+	// Poser les questions aux agents concernés en parallèle
+	var wg sync.WaitGroup
+	errs := make([]error, len(clarifications))
+	for i := range clarifications {
+		wg.Add(1)
+		go func(c *clarification, i int) {
+			defer wg.Done()
+			agent := s.Agents[c.agent.AgentID]
+			prompt := fmt.Sprintf(
+				"%s\n\nTa réponse précédente:\n%s\n\nLe coordinateur te demande une précision: %s\n\n"+
+					"Réponds uniquement à cette question, de manière concise.",
+				agent.Prompt, c.agent.Output, c.question)
+			c.answer, errs[i] = s.callModel(ctx, agent.Model, prompt, ProcessOptions{})
+		}(&clarifications[i], i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return "", &AgentError{AgentID: clarifications[i].agent.AgentID, Err: err}
+		}
+	}
+
+	return s.callModel(ctx, coordinator, buildCoordinatorPrompt(s.config.userPrompt(), results, clarifications), ProcessOptions{})
+}
+
+// buildCoordinatorPrompt construit le prompt du coordinateur. Sans précisions, il peut répondre
+// directement ou interroger des agents ; avec les précisions obtenues, il doit conclure.
+func buildCoordinatorPrompt(prompt string, results []AgentResult, clarifications []clarification) string {
+	text := fmt.Sprintf("Tu coordonnes une équipe d'agents ayant répondu à la question suivante:\n%s\n\n", prompt)
+	for _, result := range results {
+		text += fmt.Sprintf("=== Agent %d ===\n%s\n\n", result.AgentID+1, result.Output)
+	}
+
+	if clarifications == nil {
+		return text + "Produis une réponse unique et coordonnée à la question, en arbitrant entre les agents. " +
+			"Si la réponse d'un agent est ambiguë ou incomplète, tu peux d'abord lui demander une précision : " +
+			"réponds alors uniquement par une ou plusieurs lignes au format « QUESTION AGENT <numéro>: <question> »."
+	}
+
+	text += "Précisions obtenues:\n\n"
+	for _, c := range clarifications {
+		text += fmt.Sprintf("Question à l'agent %d: %s\nRéponse: %s\n\n", c.agent.AgentID+1, c.question, c.answer)
+	}
+	return text + "Produis maintenant la réponse unique et coordonnée à la question, sans poser d'autre question."
+}
+
+// parseClarifications extrait les demandes de précision adressées à des agents existants.
+// Une réponse ne contenant aucune ligne de question est considérée comme la réponse coordonnée.
+func parseClarifications(answer string, results []AgentResult) []clarification {
+	byNumber := make(map[int]AgentResult, len(results))
+	for _, result := range results {
+		byNumber[result.AgentID+1] = result
+	}
+
+	var clarifications []clarification
+	for _, line := range strings.Split(answer, "\n") {
+		match := clarificationPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		number, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		if agent, ok := byNumber[number]; ok {
+			clarifications = append(clarifications, clarification{agent: agent, question: strings.Trim(match[2], "*_ ")})
+		}
+	}
+	return clarifications
+}
@@ -0,0 +1,87 @@
+package societyai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCorpusConcurrency est le nombre de prompts traités simultanément par RunSocietyOverCorpus
+const DefaultCorpusConcurrency = 4
+
+// RunSocietyOverCorpus exécute la société dans le mode Config.Mode (ModeStandard si vide) pour chaque
+// prompt, avec la même configuration, en traitant au plus Config.CorpusConcurrency prompts à la fois.
+// Les résultats sont indexés comme prompts ; un prompt en échec laisse une entrée nil et son erreur,
+// attribuée à son indice, est jointe à l'erreur retournée. Voir CorpusTimings pour la durée agrégée.
+func RunSocietyOverCorpus(ctx context.Context, prompts []string, config *Config, models []AIModel) ([]*SocietyResult, error) {
+	mode := config.Mode
+	if mode == "" {
+		mode = ModeStandard
+	}
+	concurrency := config.CorpusConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultCorpusConcurrency
+	}
+
+	results := make([]*SocietyResult, len(prompts))
+	errs := make([]error, len(prompts))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, prompt := range prompts {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = fmt.Errorf("prompt %d: %w", i, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, prompt string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			// Chaque prompt dispose de sa propre copie de la configuration
+			promptConfig := *config
+			promptConfig.Prompt = prompt
+			result, err := RunDetailed(ctx, mode, &promptConfig, models)
+			if err != nil {
+				errs[i] = fmt.Errorf("prompt %d: %w", i, err)
+				return
+			}
+			results[i] = result
+		}(i, prompt)
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+// CorpusTiming agrège la durée des exécutions d'un corpus
+type CorpusTiming struct {
+	Runs    int           // Nombre d'exécutions réussies
+	Total   time.Duration // Somme des durées des exécutions
+	Mean    time.Duration // Durée moyenne d'une exécution
+	Longest time.Duration // Durée de l'exécution la plus longue
+}
+
+// CorpusTimings agrège la durée des résultats retournés par RunSocietyOverCorpus (entrées nil ignorées)
+func CorpusTimings(results []*SocietyResult) CorpusTiming {
+	var timing CorpusTiming
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		timing.Runs++
+		timing.Total += result.Duration
+		if result.Duration > timing.Longest {
+			timing.Longest = result.Duration
+		}
+	}
+	if timing.Runs > 0 {
+		timing.Mean = timing.Total / time.Duration(timing.Runs)
+	}
+	return timing
+}
@@ -0,0 +1,123 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SocietyDebate fait débattre agentCount agents pendant rounds tours (au moins un) : au premier tour,
+// chaque agent répond indépendamment selon sa perspective ; à chaque tour suivant, il reçoit les positions
+// des autres agents au tour précédent, les critique et affine la sienne. Les positions du dernier tour
+// sont ensuite synthétisées en un consensus par le modèle de l'agent principal.
+// Le résultat conserve les sorties de chaque tour (Result.Rounds) et le consensus (Result.Synthesis).
+func SocietyDebate(ctx context.Context, prompt string, agentCount int, models []AIModel, rounds int) (*Result, error) {
+	return RunSocietyDebate(ctx, NewConfig(prompt, agentCount), models, rounds)
+}
+
+// RunSocietyDebate fait débattre les agents de la configuration pendant rounds tours, comme SocietyDebate.
+// Avec Config.Checkpointer, les positions sont enregistrées après chaque tour et un débat interrompu
+// reprend après le dernier tour enregistré ; l'état est supprimé une fois tous les tours terminés.
+func RunSocietyDebate(ctx context.Context, config *Config, models []AIModel, rounds int) (*Result, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateModels(models); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if rounds < 1 {
+		rounds = 1
+	}
+
+	society := createSociety(config, models)
+	ctx = society.startRun(ctx)
+	ctx, cancel := society.withRunTimeout(ctx)
+	defer cancel()
+
+	key := society.checkpointKey("debate")
+	state, err := society.loadCheckpoint(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Prompt: config.Prompt}
+	start := 0
+	if state.resumable(len(society.Agents), rounds) && len(state.Rounds) == state.Round {
+		// Reprendre après le dernier tour enregistré
+		society.restoreCheckpoint(state)
+		result.Rounds = state.Rounds
+		start = state.Round
+	}
+	for round := start; round < rounds; round++ {
+		if round > 0 {
+			// Chaque agent reçoit les positions des autres agents au tour précédent
+			previous := society.agentResults
+			for _, agent := range society.Agents {
+				agent.Prompt = buildDebatePrompt(config, agent, previous)
+			}
+		}
+
+		if err := society.run(ctx); err != nil {
+			return nil, fmt.Errorf("tour %d du débat: %w", round+1, err)
+		}
+		result.Rounds = append(result.Rounds, agentOutputsOf(society.agentResults))
+		if err := society.saveCheckpoint(ctx, key, &roundState{Round: round + 1, Rounds: result.Rounds}); err != nil {
+			return nil, err
+		}
+	}
+	if err := society.clearCheckpoint(ctx, key); err != nil {
+		return nil, err
+	}
+	result.AgentOutputs = result.Rounds[len(result.Rounds)-1]
+
+	// Synthétiser les positions finales en un consensus
+	consensus, err := society.synthesize(ctx, society.primaryAgent().Model, society.activeResults())
+	if err != nil {
+		return nil, fmt.Errorf("consensus du débat: %w", err)
+	}
+	result.Synthesis = society.cleanOutput(consensus)
+	result.TotalUsage = society.usage.load()
+	result.formatted = formatDebate(result)
+	return result, nil
+}
+
+// buildDebatePrompt construit le prompt d'un tour de débat : la question, la position précédente
+// de l'agent et celles des autres agents, qu'il doit critiquer avant d'affiner la sienne
+func buildDebatePrompt(config *Config, agent *Agent, previous []AgentResult) string {
+	var own string
+	var others strings.Builder
+	for _, result := range previous {
+		if result.AgentID == agent.ID {
+			own = result.Output
+			continue
+		}
+		if result.Skipped {
+			continue
+		}
+		fmt.Fprintf(&others, "=== Agent %d ===\n%s\n\n", result.AgentID+1, result.Output)
+	}
+
+	return fmt.Sprintf(
+		"%sQuestion: %s\n\nTa position au tour précédent:\n%s\n\nPositions des autres agents:\n%s"+
+			"Critique les positions des autres agents, puis affine ta propre position : conserve ce qui "+
+			"résiste à la critique, corrige ce qui ne tient pas et intègre les arguments convaincants. "+
+			"Retourne uniquement ta position affinée.",
+		PerspectiveForAgent(config, agent.ID), config.userPrompt(), own, others.String()) +
+		config.agentInstructions(agent.ID)
+}
+
+// formatDebate présente les positions de chaque tour puis le consensus du débat
+func formatDebate(result *Result) string {
+	var text strings.Builder
+	for round, outputs := range result.Rounds {
+		fmt.Fprintf(&text, "Tour %d:\n\n", round+1)
+		for _, output := range outputs {
+			fmt.Fprintf(&text, "Agent %d: %s\n\n", output.AgentID+1, output.Output)
+		}
+	}
+	text.WriteString("Consensus:\n" + result.Synthesis)
+	return text.String()
+}
@@ -0,0 +1,58 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// subQuestionPattern reconnaît un élément de liste numérotée ou à puces (« 1. », « 2) », « - », « * »)
+var subQuestionPattern = regexp.MustCompile(`^\s*(?:\d+\s*[.):\-]|[-*•])\s*(.+)$`)
+
+// decomposePrompt demande au modèle du premier agent de découper le prompt en autant de sous-questions
+// que d'agents, puis attribue une sous-question à chaque agent. Si la décomposition échoue ou ne
+// contient pas assez de sous-questions, les agents conservent leurs prompts fondés sur les perspectives.
+func (s *SocietyGroup) decomposePrompt(ctx context.Context) {
+	if len(s.Agents) == 0 {
+		return
+	}
+
+	prompt := fmt.Sprintf(
+		"Décompose la demande suivante en exactement %d sous-questions complémentaires, "+
+			"qui couvrent ensemble toute la demande sans se recouvrir.\n\nDemande: %s\n\n"+
+			"Retourne uniquement la liste numérotée des sous-questions, une par ligne.",
+		len(s.Agents), s.config.userPrompt())
+	response, err := s.callModel(ctx, s.primaryAgent().Model, prompt, ProcessOptions{})
+	s.advance(PhaseDecompose)
+	if err != nil {
+		return
+	}
+
+	questions := parseSubQuestions(response)
+	if len(questions) < len(s.Agents) {
+		return
+	}
+	for i, agent := range s.Agents {
+		agent.Perspective = questions[i]
+		agent.Prompt = fmt.Sprintf(
+			"Dans le cadre de la demande suivante: %s\n\nRéponds précisément à cette sous-question: %s",
+			s.config.userPrompt(), questions[i]) + s.config.agentInstructions(i)
+	}
+}
+
+// parseSubQuestions extrait les sous-questions d'une liste numérotée ou à puces, en ignorant
+// les lignes d'introduction ou de conclusion éventuelles
+func parseSubQuestions(response string) []string {
+	var questions []string
+	for _, line := range strings.Split(response, "\n") {
+		match := subQuestionPattern.FindStringSubmatch(strings.TrimPrefix(strings.TrimSpace(line), "**"))
+		if match == nil {
+			continue
+		}
+		if question := strings.Trim(match[1], "*_ "); question != "" {
+			questions = append(questions, question)
+		}
+	}
+	return questions
+}
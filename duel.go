@@ -0,0 +1,96 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DuelModels fait répondre les modèles a et b au même prompt, puis demande au modèle juge
+// de désigner la meilleure réponse. Retourne le nom du modèle gagnant et la justification du juge.
+func DuelModels(ctx context.Context, prompt string, a, b AIModel, judge AIModel) (winner string, rationale string, err error) {
+	if a == nil || b == nil || judge == nil {
+		return "", "", ErrNoModelsSpecified
+	}
+
+	// Les deux modèles répondent en parallèle
+	models := []AIModel{a, b}
+	answers := make([]string, len(models))
+	errs := make([]error, len(models))
+	var wg sync.WaitGroup
+	for i, model := range models {
+		wg.Add(1)
+		go func(i int, model AIModel) {
+			defer wg.Done()
+			answers[i], errs[i] = model.Process(ctx, prompt)
+		}(i, model)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return "", "", fmt.Errorf("réponse du modèle %s: %w", models[i].Name(), err)
+		}
+	}
+
+	verdict, err := judge.Process(ctx, buildDuelPrompt(prompt, answers[0], answers[1]))
+	if err != nil {
+		return "", "", fmt.Errorf("jugement du duel: %w", err)
+	}
+
+	choice, rationale, ok := parseDuelVerdict(verdict)
+	if !ok {
+		return "", verdict, fmt.Errorf("%w: %q", ErrNoDuelWinner, verdict)
+	}
+	if choice == "B" {
+		return b.Name(), rationale, nil
+	}
+	return a.Name(), rationale, nil
+}
+
+// buildDuelPrompt construit le prompt demandant au juge de départager les deux réponses
+func buildDuelPrompt(prompt, answerA, answerB string) string {
+	return fmt.Sprintf(
+		"Compare deux réponses à la même question et désigne la meilleure "+
+			"(exactitude, pertinence, complétude, clarté).\n\nQuestion:\n%s\n\n"+
+			"=== Réponse A ===\n%s\n\n=== Réponse B ===\n%s\n\n"+
+			"Réponds exactement au format suivant:\nGAGNANT: A ou B\nJUSTIFICATION: ton raisonnement",
+		prompt, answerA, answerB)
+}
+
+// parseDuelVerdict extrait le choix (« A » ou « B ») et la justification du verdict du juge,
+// en tolérant la casse, le markdown et les variantes anglaises (WINNER, RATIONALE)
+func parseDuelVerdict(verdict string) (choice, rationale string, ok bool) {
+	lines := strings.Split(verdict, "\n")
+	for i, line := range lines {
+		content := strings.Trim(strings.TrimSpace(line), "#*_ ")
+		var rest string
+		switch {
+		case hasPrefixFold(content, "GAGNANT"):
+			rest = content[len("GAGNANT"):]
+		case hasPrefixFold(content, "WINNER"):
+			rest = content[len("WINNER"):]
+		default:
+			continue
+		}
+		rest = strings.ToUpper(strings.Trim(strings.TrimSpace(strings.TrimLeft(rest, " :*_")), ".*_ "))
+		if rest == "" || (rest[0] != 'A' && rest[0] != 'B') {
+			continue
+		}
+		if len(rest) > 1 && (rest[1] >= 'A' && rest[1] <= 'Z') {
+			// Un mot commençant par A ou B (« AUCUN »...) n'est pas un choix
+			continue
+		}
+
+		choice = rest[:1]
+		rationale = strings.TrimSpace(strings.Join(lines[i+1:], "\n"))
+		for _, label := range []string{"JUSTIFICATION", "RATIONALE"} {
+			if hasPrefixFold(strings.Trim(rationale, "#*_ "), label) {
+				rationale = strings.Trim(rationale, "#*_ ")[len(label):]
+				rationale = strings.TrimSpace(strings.TrimLeft(rationale, " :*_"))
+			}
+		}
+		return choice, rationale, true
+	}
+	return "", "", false
+}
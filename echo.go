@@ -0,0 +1,40 @@
+package societyai
+
+// DefaultEchoThreshold est la similarité avec le prompt à partir de laquelle une réponse
+// est considérée comme une simple reprise de la question (Config.FilterPromptEchoes)
+const DefaultEchoThreshold = 0.8
+
+// filterPromptEchoes repère les agents dont la réponse reprend leur prompt au lieu d'y répondre
+// (similarité au moins égale au seuil) : ces réponses sont marquées Echo et écartées des résultats.
+// Si toutes les réponses sont des reprises, elles sont seulement marquées, pour ne pas tout perdre.
+func (s *SocietyGroup) filterPromptEchoes(results []AgentResult) {
+	if s.config == nil || !s.config.FilterPromptEchoes {
+		return
+	}
+
+	threshold := s.config.EchoThreshold
+	if threshold == 0 {
+		threshold = DefaultEchoThreshold
+	}
+	similarity := s.similarity()
+
+	remaining := 0
+	for i := range results {
+		if results[i].Skipped || results[i].Err != nil {
+			continue
+		}
+		if similarity(results[i].Output, results[i].Prompt) >= threshold {
+			results[i].Echo = true
+		} else {
+			remaining++
+		}
+	}
+	if remaining == 0 {
+		return
+	}
+	for i := range results {
+		if results[i].Echo {
+			results[i].Skipped = true
+		}
+	}
+}
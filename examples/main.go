@@ -33,6 +33,8 @@ type GeminiModel struct {
 	Conversation []Message
 	MaxRetries   int
 	RetryDelay   time.Duration
+
+	lastFinishReason string
 }
 
 // Message représente un message dans la conversation
@@ -52,6 +54,21 @@ type Content struct {
 	Parts []Part `json:"parts"`
 }
 
+// BlockedError signale une requête bloquée par l'API (implémente l'interface societyai.BlockedError)
+type BlockedError struct {
+	Reason string
+}
+
+// Error implémente l'interface error
+func (e *BlockedError) Error() string {
+	return "requête bloquée: " + e.Reason
+}
+
+// Blocked indique que la réponse a été bloquée
+func (e *BlockedError) Blocked() bool {
+	return true
+}
+
 // NewGeminiModel crée un nouveau modèle Gemini
 func NewGeminiModel(modelName, apiKey string) *GeminiModel {
 	return &GeminiModel{
@@ -70,8 +87,39 @@ func (m *GeminiModel) Name() string {
 	return m.ModelName
 }
 
+// LastFinishReason retourne la raison de fin du dernier appel (implémente l'interface societyai.FinishReporter)
+func (m *GeminiModel) LastFinishReason() string {
+	return m.lastFinishReason
+}
+
+// TrimTo ne conserve que les maxTurns derniers échanges de la conversation
+// (implémente l'interface societyai.ConversationTrimmer)
+func (m *GeminiModel) TrimTo(maxTurns int) {
+	keep := maxTurns * 2 // Un échange correspond à un message utilisateur et à la réponse du modèle
+	if keep < 0 {
+		keep = 0
+	}
+	if len(m.Conversation) > keep {
+		m.Conversation = append([]Message{}, m.Conversation[len(m.Conversation)-keep:]...)
+	}
+}
+
 // Process envoie une requête à l'API Gemini et retourne la réponse (implémente l'interface AIModel)
 func (m *GeminiModel) Process(ctx context.Context, prompt string) (string, error) {
+	return m.ProcessWithOptions(ctx, prompt, societyai.ProcessOptions{})
+}
+
+// ProcessWithOptions envoie une requête à l'API Gemini avec les paramètres de génération fournis
+// (implémente l'interface societyai.ConfigurableAIModel) ; les paramètres nuls reprennent
+// les valeurs par défaut de l'exemple
+func (m *GeminiModel) ProcessWithOptions(ctx context.Context, prompt string, opts societyai.ProcessOptions) (string, error) {
+	if opts.Temperature == 0 {
+		opts.Temperature = 0.7
+	}
+	if opts.MaxTokens == 0 {
+		opts.MaxTokens = 2048
+	}
+
 	// Ajouter le message utilisateur à la conversation
 	m.Conversation = append(m.Conversation, Message{
 		Role:    "user",
@@ -98,15 +146,18 @@ func (m *GeminiModel) Process(ctx context.Context, prompt string) (string, error
 		GenerationConfig struct {
 			MaxOutputTokens int     `json:"maxOutputTokens,omitempty"`
 			Temperature     float64 `json:"temperature,omitempty"`
+			TopP            float64 `json:"topP,omitempty"`
 		} `json:"generationConfig,omitempty"`
 	}{
 		Contents: contents,
 		GenerationConfig: struct {
 			MaxOutputTokens int     `json:"maxOutputTokens,omitempty"`
 			Temperature     float64 `json:"temperature,omitempty"`
+			TopP            float64 `json:"topP,omitempty"`
 		}{
-			MaxOutputTokens: 2048,
-			Temperature:     0.7,
+			MaxOutputTokens: opts.MaxTokens,
+			Temperature:     opts.Temperature,
+			TopP:            opts.TopP,
 		},
 	}
 
@@ -175,16 +226,19 @@ func (m *GeminiModel) Process(ctx context.Context, prompt string) (string, error
 			continue
 		}
 
+		// Vérifier si la requête a été bloquée pour des raisons de sécurité
+		// (inutile de réessayer : le même prompt sera de nouveau bloqué)
+		if result.PromptFeedback.BlockReason != "" {
+			m.lastFinishReason = result.PromptFeedback.BlockReason
+			return "", &BlockedError{Reason: result.PromptFeedback.BlockReason}
+		}
+
 		if len(result.Candidates) == 0 {
 			lastError = fmt.Errorf("réponse vide reçue de l'API")
 			continue
 		}
 
-		// Vérifier si la requête a été bloquée pour des raisons de sécurité
-		if result.PromptFeedback.BlockReason != "" {
-			lastError = fmt.Errorf("requête bloquée: %s", result.PromptFeedback.BlockReason)
-			continue
-		}
+		m.lastFinishReason = result.Candidates[0].FinishReason
 
 		// Obtenir la réponse textuelle
 		responseText := ""
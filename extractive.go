@@ -0,0 +1,94 @@
+package societyai
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultExtractiveSupportThreshold est la part minimale des mots d'une phrase de la synthèse qui doivent
+// figurer dans la réponse d'un même agent pour que la phrase soit considérée comme appuyée
+const DefaultExtractiveSupportThreshold = 0.5
+
+// minVerifiedWords est le nombre de mots en deçà duquel une phrase (titre, transition) n'est pas vérifiée
+const minVerifiedWords = 4
+
+// extractiveInstruction retourne la consigne limitant la synthèse aux affirmations des agents (Config.ExtractiveSynthesis)
+func extractiveInstruction(lang Language) string {
+	if lang == LanguageEnglish {
+		return "Compose the answer solely from the agents' statements: reorganize and merge them, " +
+			"but do not add any information, example or claim that does not appear in their answers."
+	}
+	return "Compose la réponse uniquement à partir des affirmations des agents : réorganise-les et fusionne-les, " +
+		"sans ajouter aucune information, aucun exemple ni aucune affirmation absents de leurs réponses."
+}
+
+// unsupportedStatements retourne les phrases de la synthèse dont la part de mots présents dans la réponse
+// d'un même agent reste sous le seuil. La mesure est une inclusion (mots de la phrase retrouvés dans la
+// réponse) plutôt qu'une similarité symétrique, une phrase étant bien plus courte qu'une réponse entière.
+func unsupportedStatements(synthesis string, outputs []string, threshold float64) []string {
+	if threshold <= 0 {
+		threshold = DefaultExtractiveSupportThreshold
+	}
+
+	sources := make([]map[string]bool, len(outputs))
+	for i, output := range outputs {
+		sources[i] = wordSet(output)
+	}
+
+	var unsupported []string
+	for _, sentence := range splitSentences(synthesis) {
+		words := wordSet(sentence)
+		if len(words) < minVerifiedWords {
+			continue
+		}
+
+		best := 0.0
+		for _, source := range sources {
+			found := 0
+			for word := range words {
+				if source[word] {
+					found++
+				}
+			}
+			if support := float64(found) / float64(len(words)); support > best {
+				best = support
+			}
+		}
+		if best < threshold {
+			unsupported = append(unsupported, sentence)
+		}
+	}
+	return unsupported
+}
+
+// splitSentences découpe un texte en phrases, sur les ponctuations finales et les retours à la ligne
+func splitSentences(text string) []string {
+	var sentences []string
+	var current strings.Builder
+	flush := func() {
+		if sentence := strings.TrimSpace(current.String()); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		current.Reset()
+	}
+
+	for _, r := range text {
+		if r == '\n' {
+			flush()
+			continue
+		}
+		current.WriteRune(r)
+		if r == '.' || r == '!' || r == '?' {
+			flush()
+		}
+	}
+	flush()
+
+	// Les puces et numérotations de liste ne font pas partie de la phrase
+	for i, sentence := range sentences {
+		sentences[i] = strings.TrimLeftFunc(sentence, func(r rune) bool {
+			return r == '-' || r == '*' || r == '•' || unicode.IsSpace(r)
+		})
+	}
+	return sentences
+}
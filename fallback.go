@@ -0,0 +1,80 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// fallbackModel essaie les modèles enveloppés dans l'ordre jusqu'au premier succès
+type fallbackModel struct {
+	models []AIModel
+	active atomic.Int64 // Indice du dernier modèle ayant répondu
+}
+
+// NewFallbackModel crée un modèle qui essaie chaque modèle dans l'ordre jusqu'à ce que l'un d'eux
+// réponde (par exemple Gemini, puis OpenAI, puis un modèle local) et ne retourne l'erreur du dernier
+// modèle que si tous ont échoué. Les modèles nil sont ignorés. Name retourne le nom du dernier modèle
+// ayant répondu, ou celui du premier modèle tant qu'aucun n'a répondu. L'annulation du contexte
+// interrompt la chaîne, et les paramètres de génération sont transmis aux modèles implémentant
+// ConfigurableAIModel.
+func NewFallbackModel(models ...AIModel) AIModel {
+	chain := make([]AIModel, 0, len(models))
+	for _, model := range models {
+		if model != nil {
+			chain = append(chain, model)
+		}
+	}
+	return &fallbackModel{models: chain}
+}
+
+// Name implémente l'interface AIModel
+func (m *fallbackModel) Name() string {
+	if len(m.models) == 0 {
+		return "fallback"
+	}
+	return m.models[m.active.Load()].Name()
+}
+
+// Process implémente l'interface AIModel
+func (m *fallbackModel) Process(ctx context.Context, prompt string) (string, error) {
+	return m.ProcessWithOptions(ctx, prompt, ProcessOptions{})
+}
+
+// ProcessWithOptions implémente l'interface ConfigurableAIModel
+func (m *fallbackModel) ProcessWithOptions(ctx context.Context, prompt string, opts ProcessOptions) (string, error) {
+	if len(m.models) == 0 {
+		return "", ErrNoModelsSpecified
+	}
+
+	var lastErr error
+	for i, model := range m.models {
+		// Inutile d'essayer le modèle suivant si le contexte de l'appelant est terminé
+		if err := ctx.Err(); err != nil {
+			if lastErr == nil {
+				return "", err
+			}
+			return "", fmt.Errorf("abandon après l'échec de %d modèle(s): %w", i, lastErr)
+		}
+
+		result, err := processWithOptions(ctx, model, prompt, opts)
+		if err == nil {
+			m.active.Store(int64(i))
+			return result, nil
+		}
+		lastErr = fmt.Errorf("%s: %w", model.Name(), err)
+	}
+
+	return "", fmt.Errorf("échec des %d modèle(s): %w", len(m.models), lastErr)
+}
+
+// LastUsage implémente l'interface UsageReporter en rapportant la consommation du dernier modèle ayant répondu
+func (m *fallbackModel) LastUsage() Usage {
+	if len(m.models) == 0 {
+		return Usage{}
+	}
+	if reporter, ok := m.models[m.active.Load()].(UsageReporter); ok {
+		return reporter.LastUsage()
+	}
+	return Usage{}
+}
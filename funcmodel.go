@@ -0,0 +1,25 @@
+package societyai
+
+import "context"
+
+// funcModel adapte une fonction à l'interface AIModel
+type funcModel struct {
+	name string
+	fn   func(ctx context.Context, prompt string) (string, error)
+}
+
+// NewFuncModel crée un AIModel à partir d'un nom et d'une fonction de traitement des prompts,
+// sans avoir à déclarer de type (prototypes, exemples, tests)
+func NewFuncModel(name string, fn func(ctx context.Context, prompt string) (string, error)) AIModel {
+	return &funcModel{name: name, fn: fn}
+}
+
+// Name implémente l'interface AIModel
+func (m *funcModel) Name() string {
+	return m.name
+}
+
+// Process implémente l'interface AIModel
+func (m *funcModel) Process(ctx context.Context, prompt string) (string, error) {
+	return m.fn(ctx, prompt)
+}
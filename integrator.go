@@ -0,0 +1,45 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+)
+
+// DimensionInsight associe une dimension explorée à l'analyse produite pour cette dimension
+type DimensionInsight struct {
+	Dimension string // Dimension explorée
+	Insight   string // Analyse produite par l'agent chargé de la dimension
+}
+
+// Integrator combine l'analyse initiale et les analyses de chaque dimension en une
+// compréhension unifiée lors de la phase d'intégration du mode collaboratif.
+// Il permet d'expérimenter d'autres stratégies (fusion par paires, hiérarchique...).
+type Integrator interface {
+	// Integrate produit l'analyse intégrée à partir de l'analyse initiale et des analyses par dimension
+	Integrate(ctx context.Context, initial string, insights []DimensionInsight, model AIModel) (string, error)
+}
+
+// PromptIntegrator est l'intégrateur par défaut : il demande au modèle, en un seul appel,
+// d'intégrer organiquement toutes les dimensions
+type PromptIntegrator struct{}
+
+// Integrate implémente l'interface Integrator
+func (PromptIntegrator) Integrate(ctx context.Context, initial string, insights []DimensionInsight, model AIModel) (string, error) {
+	// Créer le prompt pour l'intégration
+	integrationPrompt := "Intègre organiquement ces différentes analyses en une compréhension cohérente et unifiée:\n\n"
+
+	// Ajouter l'analyse initiale
+	integrationPrompt += "Compréhension initiale de la demande:\n" + initial + "\n\n"
+
+	// Ajouter les analyses des différentes dimensions
+	for _, insight := range insights {
+		integrationPrompt += fmt.Sprintf("Dimension: %s\n%s\n\n", insight.Dimension, insight.Insight)
+	}
+
+	integrationPrompt += "Ta tâche est de synthétiser ces analyses en une compréhension intégrée qui combine " +
+		"organiquement toutes les dimensions, en évitant de simplement juxtaposer les informations. " +
+		"Identifie les connexions, les patterns et les idées transversales. " +
+		"Forme une analyse unifiée qui représente une réflexion collaborative approfondie."
+
+	return model.Process(ctx, integrationPrompt)
+}
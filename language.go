@@ -0,0 +1,191 @@
+package societyai
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Language identifie la langue des instructions envoyées aux modèles
+type Language string
+
+// Langues supportées
+const (
+	// LanguageFrench est la langue par défaut des instructions
+	LanguageFrench Language = "fr"
+	// LanguageEnglish produit des instructions en anglais
+	LanguageEnglish Language = "en"
+)
+
+// Mots très fréquents utilisés pour la détection heuristique de la langue
+var (
+	frenchStopWords = map[string]bool{
+		"le": true, "la": true, "les": true, "un": true, "une": true, "des": true,
+		"est": true, "et": true, "en": true, "du": true, "que": true, "qui": true,
+		"pour": true, "dans": true, "sur": true, "avec": true, "pas": true, "comment": true,
+		"quel": true, "quelle": true, "quels": true, "pourquoi": true, "je": true, "vous": true,
+	}
+	englishStopWords = map[string]bool{
+		"the": true, "a": true, "an": true, "is": true, "are": true, "and": true,
+		"of": true, "to": true, "in": true, "for": true, "on": true, "with": true,
+		"what": true, "how": true, "why": true, "which": true, "that": true, "this": true,
+		"do": true, "does": true, "i": true, "you": true, "can": true, "should": true,
+	}
+)
+
+// DetectLanguage détecte de manière heuristique la langue d'un texte.
+// La détection repose sur la fréquence des mots usuels et la présence de caractères accentués ;
+// en cas de doute, la langue par défaut (français) est retournée.
+func DetectLanguage(text string) Language {
+	french, english := 0, 0
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, word := range words {
+		if frenchStopWords[word] {
+			french++
+		}
+		if englishStopWords[word] {
+			english++
+		}
+	}
+
+	// Les caractères accentués sont un indice fort en faveur du français
+	if strings.ContainsAny(text, "éèêàùçôîœ") {
+		french++
+	}
+
+	if english > french {
+		return LanguageEnglish
+	}
+	return LanguageFrench
+}
+
+// resolveLanguage détermine la langue des instructions pour une configuration donnée
+func resolveLanguage(config *Config) Language {
+	if config == nil {
+		return LanguageFrench
+	}
+	if config.Language != "" {
+		return config.Language
+	}
+	if config.AutoLanguage {
+		return DetectLanguage(config.Prompt)
+	}
+	return LanguageFrench
+}
+
+// responseLanguageInstruction retourne l'instruction demandant aux agents de répondre dans la langue indiquée
+func responseLanguageInstruction(lang Language) string {
+	switch lang {
+	case LanguageEnglish:
+		return "\n\nAnswer in English."
+	default:
+		return "\n\nRéponds en français."
+	}
+}
+
+// audienceInstruction retourne la consigne adaptant la réponse au public visé
+func audienceInstruction(lang Language, audience string) string {
+	switch lang {
+	case LanguageEnglish:
+		return "Tailor the answer for: " + audience
+	default:
+		return "Adapte la formulation et le niveau de détail de la réponse pour ce public: " + audience
+	}
+}
+
+// maxWordsInstruction retourne la consigne limitant la longueur de la réponse d'un agent
+func maxWordsInstruction(lang Language, words int) string {
+	switch lang {
+	case LanguageEnglish:
+		return fmt.Sprintf("\n\nBe concise: answer in %d words at most.", words)
+	default:
+		return fmt.Sprintf("\n\nSois concis : réponds en %d mots au maximum.", words)
+	}
+}
+
+// diversityInstruction retourne la consigne demandant à un agent de se démarquer des autres
+func diversityInstruction(lang Language, agent, total int) string {
+	switch lang {
+	case LanguageEnglish:
+		return fmt.Sprintf("\n\nOffer a perspective not already covered by the other agents; you are agent %d of %d.", agent, total)
+	default:
+		return fmt.Sprintf("\n\nPropose une perspective que les autres agents n'ont pas encore couverte ; tu es l'agent %d sur %d.", agent, total)
+	}
+}
+
+// synthesisText regroupe les textes du prompt de synthèse pour une langue
+type synthesisText struct {
+	header      string
+	agentLabel  string
+	task        string
+	answerLabel string
+}
+
+// synthesisTexts contient les instructions de synthèse disponibles par langue
+var synthesisTexts = map[Language]synthesisText{
+	LanguageFrench: {
+		header:     "Analyse et synthétise les perspectives suivantes des agents en une réponse cohérente et approfondie:\n\n",
+		agentLabel: "AGENT",
+		task: "Ta tâche est de produire une synthèse complète qui:\n" +
+			"1. Identifie les points d'accord et de désaccord entre les agents\n" +
+			"2. Combine les perspectives uniques en une vision cohérente\n" +
+			"3. Présente une conclusion qui intègre les meilleures idées de chaque agent\n" +
+			"4. Offre une réponse finale plus complète que chacune des perspectives individuelles\n",
+		answerLabel: "Synthèse:",
+	},
+	LanguageEnglish: {
+		header:     "Analyze and synthesize the following agent perspectives into a coherent, in-depth answer:\n\n",
+		agentLabel: "AGENT",
+		task: "Your task is to produce a complete synthesis that:\n" +
+			"1. Identifies the points of agreement and disagreement between the agents\n" +
+			"2. Combines the unique perspectives into a coherent view\n" +
+			"3. Presents a conclusion that integrates the best ideas of each agent\n" +
+			"4. Offers a final answer more complete than any individual perspective\n" +
+			"Answer in English.\n",
+		answerLabel: "Synthesis:",
+	},
+}
+
+// votingTexts contient les instructions de synthèse par vote (SynthesisVoting) dans chaque langue supportée
+var votingTexts = map[Language]synthesisText{
+	LanguageFrench: {
+		header:     "Dépouille les réponses suivantes des agents comme un vote:\n\n",
+		agentLabel: "AGENT",
+		task: "Ta tâche est de:\n" +
+			"1. Extraire en une phrase la recommandation ou la réponse principale de chaque agent\n" +
+			"2. Regrouper les recommandations équivalentes et compter les agents qui soutiennent chacune\n" +
+			"3. Énoncer la position majoritaire avec son décompte (ex: 3 agents sur 5)\n" +
+			"4. Présenter les positions minoritaires et les agents qui les soutiennent, sans les fusionner avec la majorité\n",
+		answerLabel: "Résultat du vote:",
+	},
+	LanguageEnglish: {
+		header:     "Tally the following agent answers as a vote:\n\n",
+		agentLabel: "AGENT",
+		task: "Your task is to:\n" +
+			"1. Extract each agent's core recommendation or answer in one sentence\n" +
+			"2. Group equivalent recommendations and count the agents supporting each\n" +
+			"3. State the majority position with its tally (e.g. 3 agents out of 5)\n" +
+			"4. Present the dissenting positions and the agents holding them, without blending them into the majority\n" +
+			"Answer in English.\n",
+		answerLabel: "Vote result:",
+	},
+}
+
+// votingTextsFor retourne les instructions de synthèse par vote d'une langue, en français par défaut
+func votingTextsFor(lang Language) synthesisText {
+	if texts, ok := votingTexts[lang]; ok {
+		return texts
+	}
+	return votingTexts[LanguageFrench]
+}
+
+// synthesisTextsFor retourne les instructions de synthèse d'une langue, en français par défaut
+func synthesisTextsFor(lang Language) synthesisText {
+	if texts, ok := synthesisTexts[lang]; ok {
+		return texts
+	}
+	return synthesisTexts[LanguageFrench]
+}
@@ -0,0 +1,74 @@
+package societyai
+
+import (
+	"context"
+	"time"
+)
+
+// MetricsRecorder reçoit les métriques de chaque exécution. Les étiquettes transmises
+// (Config.Tags, complétées du mode) permettent de partitionner les compteurs, par client par exemple.
+type MetricsRecorder interface {
+	// IncCounter incrémente le compteur name de value
+	IncCounter(name string, value int64, tags map[string]string)
+	// ObserveDuration enregistre une durée pour la métrique name
+	ObserveDuration(name string, d time.Duration, tags map[string]string)
+}
+
+// Noms des métriques transmises au MetricsRecorder
+const (
+	// MetricRuns compte les exécutions terminées
+	MetricRuns = "societyai_runs"
+	// MetricRunErrors compte les exécutions terminées en erreur
+	MetricRunErrors = "societyai_run_errors"
+	// MetricModelCalls compte les appels envoyés aux modèles
+	MetricModelCalls = "societyai_model_calls"
+	// MetricRunDuration mesure la durée des exécutions
+	MetricRunDuration = "societyai_run_duration"
+	// MetricCacheHits compte les exécutions servies par Config.Cache
+	MetricCacheHits = "societyai_cache_hits"
+	// MetricCacheMisses compte les exécutions absentes de Config.Cache
+	MetricCacheMisses = "societyai_cache_misses"
+)
+
+// tagsKey est la clé de contexte sous laquelle les étiquettes de l'exécution sont stockées
+type tagsKey struct{}
+
+// TagsFromContext retourne les étiquettes de l'exécution (Config.Tags) portées par le contexte
+func TagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	return tags
+}
+
+// runTags retourne une copie des étiquettes de la configuration, pour qu'elles ne puissent
+// pas être modifiées par les destinataires pendant l'exécution
+func (s *SocietyGroup) runTags() map[string]string {
+	if s.config == nil || len(s.config.Tags) == 0 {
+		return nil
+	}
+	tags := make(map[string]string, len(s.config.Tags))
+	for key, value := range s.config.Tags {
+		tags[key] = value
+	}
+	return tags
+}
+
+// recordRun transmet les métriques de l'exécution terminée à Config.Metrics
+func (s *SocietyGroup) recordRun(mode Mode, err error) {
+	if s.config == nil || s.config.Metrics == nil {
+		return
+	}
+
+	tags := s.runTags()
+	if tags == nil {
+		tags = make(map[string]string, 1)
+	}
+	tags["mode"] = string(mode)
+
+	metrics := s.config.Metrics
+	metrics.IncCounter(MetricRuns, 1, tags)
+	if err != nil {
+		metrics.IncCounter(MetricRunErrors, 1, tags)
+	}
+	metrics.IncCounter(MetricModelCalls, s.modelCalls.Load(), tags)
+	metrics.ObserveDuration(MetricRunDuration, time.Since(s.startedAt), tags)
+}
@@ -0,0 +1,167 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Mode identifie le mode de fonctionnement d'une société
+type Mode string
+
+// Modes de fonctionnement disponibles
+const (
+	// ModeStandard juxtapose les perspectives des agents
+	ModeStandard Mode = "standard"
+	// ModeSynthesis synthétise les perspectives avec Config.SynthesisModel
+	ModeSynthesis Mode = "synthesis"
+	// ModeCollaborative enchaîne les quatre phases de réflexion collaborative
+	ModeCollaborative Mode = "collaborative"
+)
+
+// DefaultRetryBackoff est le délai d'attente initial entre deux tentatives de RunWithRetry
+const DefaultRetryBackoff = time.Second
+
+// RunDetailed exécute la société dans le mode indiqué et retourne le résultat détaillé.
+// Lorsque Config.Cache est défini, un résultat encore valide pour la même exécution est
+// retourné sans appel aux modèles, et les nouveaux résultats y sont enregistrés.
+func RunDetailed(ctx context.Context, mode Mode, config *Config, models []AIModel) (*SocietyResult, error) {
+	if config.Cache == nil {
+		return runMode(ctx, mode, config, models)
+	}
+	if err := validateModels(models); err != nil {
+		return nil, err
+	}
+
+	key, ok := cacheKey(mode, config, models)
+	if !ok {
+		return runMode(ctx, mode, config, models)
+	}
+	if result, hit := config.Cache.get(key); hit {
+		recordCache(config, mode, true)
+		return result, nil
+	}
+	recordCache(config, mode, false)
+
+	result, err := runMode(ctx, mode, config, models)
+	if err != nil {
+		return nil, err
+	}
+	config.Cache.set(key, config.Prompt, result)
+	return result, nil
+}
+
+// runMode exécute la société dans le mode indiqué, sans passer par le cache
+func runMode(ctx context.Context, mode Mode, config *Config, models []AIModel) (*SocietyResult, error) {
+	switch mode {
+	case ModeStandard:
+		return RunSocietyDetailed(ctx, config, models)
+	case ModeSynthesis:
+		if config.SynthesisModel == nil && config.Synthesizer == nil {
+			return nil, ErrNoSynthesisModel
+		}
+		return RunSocietyWithSynthesisDetailed(ctx, config, models, config.SynthesisModel)
+	case ModeCollaborative:
+		return runCollaborative(ctx, config, models)
+	}
+	return nil, fmt.Errorf("mode de fonctionnement inconnu: %q", mode)
+}
+
+// EstimateModelCalls estime le nombre d'appels aux modèles qu'une exécution du mode indiqué
+// va effectuer avec cette configuration, hors relances et appels groupés (BatchModel).
+// Le nombre réel d'appels est rapporté par SocietyResult.ModelCalls.
+func EstimateModelCalls(mode Mode, config *Config) int {
+	switch mode {
+	case ModeStandard:
+		calls := config.AgentCount * config.passes()
+		if config.CoordinatorModel != nil || config.UseCoordinator {
+			// Un appel de coordination, hors tour de précisions
+			calls++
+		}
+		return calls + preparationCalls(config)
+	case ModeSynthesis:
+		// Un appel par agent et par passe, les synthèses intermédiaires éventuelles puis un appel de synthèse
+		calls := config.AgentCount*config.passes() + 1 + config.treeSynthesisCalls(config.AgentCount)
+		if config.SynthesisCandidates > 1 {
+			// Une synthèse par candidate puis un appel de sélection
+			calls += config.SynthesisCandidates
+		}
+		return calls + preparationCalls(config)
+	case ModeCollaborative:
+		// Analyse initiale, une exploration par agent, intégration et réponse finale
+		calls := 1 + config.AgentCount + 1 + 1
+		if config.InitialAnalysis != "" {
+			// Analyse initiale fournie par la configuration
+			calls--
+		}
+		if config.IncludeSummary {
+			calls++
+		}
+		return calls
+	}
+	return 0
+}
+
+// preparationCalls retourne le nombre d'appels de préparation des prompts des agents
+// en modes standard et synthèse (décomposition, reformulations, y compris celles servies par Config.RephraseCache)
+func preparationCalls(config *Config) int {
+	switch {
+	case config.DecomposePrompt:
+		return 1
+	case config.RephraseQuestions:
+		return config.AgentCount
+	}
+	return 0
+}
+
+// RunWithRetry exécute la société dans le mode indiqué et relance l'ensemble du pipeline
+// en cas d'échec, jusqu'à maxAttempts tentatives, avec un délai d'attente doublé à chaque
+// nouvelle tentative (Config.RetryBackoff, DefaultRetryBackoff par défaut).
+// Lorsque l'échéance de ctx tombe avant la fin du délai d'attente, la fonction rend la main immédiatement
+// avec l'erreur de la dernière tentative. Chaque tentative construit une nouvelle société. Contrairement aux relances par appel de modèle,
+// cette fonction couvre les échecs de bout en bout (délai dépassé, synthèse en échec...).
+func RunWithRetry(ctx context.Context, mode Mode, config *Config, models []AIModel, maxAttempts int) (string, error) {
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	backoff := config.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	var lastErr error
+	attempt := 0
+	for attempt < maxAttempts {
+		if attempt > 0 {
+			// Inutile d'attendre si l'échéance du contexte tombe avant la fin du délai d'attente
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+				return "", fmt.Errorf("abandon après %d tentative(s), échéance trop proche pour une nouvelle tentative: %w", attempt, lastErr)
+			}
+
+			// Attendre avant la tentative suivante sans ignorer l'annulation du contexte
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return "", fmt.Errorf("abandon après %d tentative(s): %w", attempt, lastErr)
+			}
+			backoff *= 2
+		}
+		attempt++
+
+		result, err := RunDetailed(ctx, mode, config, models)
+		if err == nil {
+			return result.Response, nil
+		}
+		lastErr = err
+
+		// Inutile de relancer si le contexte de l'appelant est terminé
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return "", fmt.Errorf("échec après %d tentative(s): %w", attempt, lastErr)
+}
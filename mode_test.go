@@ -0,0 +1,33 @@
+package societyai_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benoitpetit/societyai"
+	"github.com/benoitpetit/societyai/testmodel"
+)
+
+func TestRunWithRetryStopsBeforeDeadline(t *testing.T) {
+	errModel := errors.New("modèle indisponible")
+	model := testmodel.New("model", nil).FailWith(errModel)
+
+	config := societyai.NewConfig("Quelle base de données choisir ?", 1)
+	config.RetryBackoff = time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := societyai.RunWithRetry(ctx, societyai.ModeStandard, config, []societyai.AIModel{model}, 5)
+	if elapsed := time.Since(start); elapsed >= config.RetryBackoff {
+		t.Errorf("RunWithRetry a attendu %v, plus que le délai entre tentatives", elapsed)
+	}
+	if !errors.Is(err, errModel) {
+		t.Fatalf("erreur attendue %v, obtenu %v", errModel, err)
+	}
+	if calls := model.Calls(); calls != 1 {
+		t.Errorf("%d appel(s) au modèle, une seule tentative attendue", calls)
+	}
+}
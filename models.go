@@ -3,6 +3,11 @@ package societyai
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // AIModel définit l'interface pour les modèles d'IA
@@ -15,23 +20,150 @@ type AIModel interface {
 	Name() string
 }
 
+// ProcessOptions regroupe les paramètres de génération transmis aux modèles qui les supportent.
+// Une valeur nulle laisse le modèle utiliser son propre paramètre par défaut.
+type ProcessOptions struct {
+	Temperature float64 // Température d'échantillonnage
+	MaxTokens   int     // Nombre maximal de tokens générés
+	TopP        float64 // Seuil de probabilité cumulée (nucleus sampling)
+}
+
+// ConfigurableAIModel est une interface optionnelle pour les modèles acceptant des paramètres de génération.
+// Les modèles qui n'implémentent ni cette interface ni ParameterizedAIModel sont appelés via Process
+// et ignorent ces paramètres.
+type ConfigurableAIModel interface {
+	AIModel
+	// ProcessWithOptions traite un prompt avec les paramètres de génération fournis
+	ProcessWithOptions(ctx context.Context, prompt string, opts ProcessOptions) (string, error)
+}
+
+// ModelParams est un autre nom de ProcessOptions, pour les modèles implémentant ParameterizedAIModel
+type ModelParams = ProcessOptions
+
+// ParameterizedAIModel est une variante de ConfigurableAIModel pour les modèles exposant ProcessWithParams.
+// Lorsqu'un modèle implémente les deux interfaces, ProcessWithOptions est utilisée.
+type ParameterizedAIModel interface {
+	AIModel
+	// ProcessWithParams traite un prompt avec les paramètres de génération fournis
+	ProcessWithParams(ctx context.Context, prompt string, params ModelParams) (string, error)
+}
+
+// PhaseTemperatures définit la température utilisée à chaque phase du mode collaboratif.
+// Une température nulle reprend la valeur de DefaultPhaseTemperatures pour la phase concernée.
+type PhaseTemperatures struct {
+	Initial   float64 // Analyse initiale
+	Explore   float64 // Exploration des dimensions (divergente)
+	Integrate float64 // Intégration des analyses (convergente)
+	Final     float64 // Réponse finale (convergente)
+}
+
+// DefaultPhaseTemperatures contient le calendrier de températures appliqué par défaut :
+// élevé pour l'exploration, plus bas pour l'intégration et la réponse finale.
+// L'analyse initiale conserve la température par défaut du modèle.
+var DefaultPhaseTemperatures = PhaseTemperatures{
+	Explore:   0.9,
+	Integrate: 0.4,
+	Final:     0.5,
+}
+
+// Bornes des températures réparties entre les agents des modes standard et synthèse
+// (voir Config.DisableTemperatureSpread)
+const (
+	// DefaultAgentTemperatureMin est la température du premier agent
+	DefaultAgentTemperatureMin = 0.2
+	// DefaultAgentTemperatureMax est la température du dernier agent
+	DefaultAgentTemperatureMax = 0.9
+)
+
+// withDefaults complète les températures non renseignées avec les valeurs par défaut
+func (t PhaseTemperatures) withDefaults() PhaseTemperatures {
+	if t.Initial == 0 {
+		t.Initial = DefaultPhaseTemperatures.Initial
+	}
+	if t.Explore == 0 {
+		t.Explore = DefaultPhaseTemperatures.Explore
+	}
+	if t.Integrate == 0 {
+		t.Integrate = DefaultPhaseTemperatures.Integrate
+	}
+	if t.Final == 0 {
+		t.Final = DefaultPhaseTemperatures.Final
+	}
+	return t
+}
+
+// BatchModel est une interface optionnelle pour les modèles capables de traiter
+// plusieurs prompts en une seule requête. Lorsque plusieurs agents partagent un tel modèle,
+// la société leur envoie leurs prompts en un seul appel groupé plutôt qu'un appel par agent.
+// Les prompts du lot sont compressés comme les autres (Config.MaxPromptChars), mais un lot ne transmet
+// aucun paramètre de génération : un agent dont le modèle accepte des paramètres (ConfigurableAIModel,
+// ParameterizedAIModel) et qui en reçoit (étalement des températures, Config.AgentMaxWords) est donc
+// traité individuellement.
+type BatchModel interface {
+	AIModel
+	// ProcessBatch traite les prompts et retourne une réponse par prompt, dans le même ordre
+	ProcessBatch(ctx context.Context, prompts []string) ([]string, error)
+}
+
+// Phases du mode collaboratif, transmises notamment à Config.PhaseGate
+const (
+	// PhaseInitial désigne l'analyse initiale du prompt
+	PhaseInitial = "initial analysis"
+	// PhaseExplore désigne l'exploration des dimensions
+	PhaseExplore = "explore dimensions"
+	// PhaseIntegrate désigne l'intégration des analyses
+	PhaseIntegrate = "integrate"
+	// PhaseFinal désigne la génération de la réponse finale
+	PhaseFinal = "final response"
+	// PhaseAgents désigne l'exécution des agents en mode standard
+	PhaseAgents = "agents"
+	// PhaseDecompose désigne la décomposition du prompt en sous-questions (Config.DecomposePrompt)
+	PhaseDecompose = "decompose"
+	// PhaseRephrase désigne la reformulation de la question pour chaque agent (Config.RephraseQuestions)
+	PhaseRephrase = "rephrase"
+	// PhaseSynthesis désigne la combinaison des réponses des agents (synthèse ou coordination)
+	PhaseSynthesis = "synthesis"
+)
+
+// Détails transmis à Config.OnPhase au début et à la fin de chaque phase collaborative ;
+// pendant l'exploration, chaque agent terminé est signalé par "agent <id>: <dimension>"
+const (
+	// PhaseStarted signale le début d'une phase
+	PhaseStarted = "start"
+	// PhaseCompleted signale la fin d'une phase
+	PhaseCompleted = "end"
+)
+
+// FinishReporter est une interface optionnelle qu'un modèle peut implémenter pour indiquer
+// pourquoi sa dernière génération s'est terminée (fin naturelle, limite de tokens, filtre de sécurité...).
+// La société lit cette valeur juste après chaque appel à Process ; un modèle partagé entre
+// plusieurs agents concurrents peut donc rapporter la raison d'un autre appel.
+type FinishReporter interface {
+	// LastFinishReason retourne la raison de fin du dernier appel à Process
+	LastFinishReason() string
+}
+
 // Agent représente un agent individuel dans la société
 type Agent struct {
 	ID                 int
 	Model              AIModel
 	Prompt             string
-	Results            chan string
-	Phase              int    // Phase actuelle de réflexion de l'agent
-	CollabContext      string // Contexte collaboratif partagé entre les agents
-	SharedAnalysis     string // Analyse partagée générée par le groupe
-	DimensionToExplore string // Dimension spécifique explorée par cet agent
+	Results            chan string // Deprecated: n'est plus alimenté, le résultat de l'agent figure dans SocietyResult.Agents
+	Phase              int         // Phase actuelle de réflexion de l'agent
+	CollabContext      string      // Contexte collaboratif partagé entre les agents
+	SharedAnalysis     string      // Analyse partagée générée par le groupe
+	DimensionToExplore string      // Dimension spécifique explorée par cet agent
+	FinishReason       string      // Raison de fin rapportée par le modèle lors du dernier appel
+	Perspective        string      // Perspective attribuée à l'agent (modes standard et synthèse)
 }
 
 // CollaborativeContext représente le contexte partagé entre les agents
 type CollaborativeContext struct {
 	InitialAnalysis string   // Analyse initiale du prompt
 	Dimensions      []string // Dimensions explorées par les agents
-	SharedInsights  []string // Observations partagées entre les agents
+	SharedInsights  []string // Observations partagées, SharedInsights[i] provenant de l'agent i
+
+	IntegratedAnalysis string // Analyse intégrée produite à partir des observations
 }
 
 // SocietyGroup représente une société d'agents
@@ -39,8 +171,31 @@ type SocietyGroup struct {
 	Agents     []*Agent
 	Models     []AIModel
 	MultiModel bool
-	Results    chan string
+	Results    chan string           // Deprecated: n'est plus alimenté, chaque agent enregistre son résultat ou son erreur une seule fois
 	Context    *CollaborativeContext // Contexte collaboratif partagé
+	Scratchpad *Scratchpad           // Notes partagées entre les agents (Config.Scratchpad)
+
+	config                *Config                  // Configuration ayant servi à créer la société
+	agentResults          []AgentResult            // Résultats individuels des agents, dans l'ordre des agents
+	synthesis             string                   // Synthèse produite par le modèle de synthèse
+	synthesisCandidates   []string                 // Synthèses candidates (Config.SynthesisCandidates)
+	droppedAgents         []int                    // Agents écartés de la synthèse (Config.MaxSynthesisInputTokens)
+	unsupportedStatements []string                 // Phrases de la synthèse non appuyées par les agents (Config.VerifyExtractiveSynthesis)
+	modelCalls            atomic.Int64             // Nombre d'appels effectivement envoyés aux modèles
+	usage                 usageCounter             // Tokens consommés par les appels aux modèles (UsageReporter)
+	runID                 string                   // Identifiant de l'exécution en cours
+	routing               []RoutingDecision        // Attribution des modèles aux dimensions (Config.SmartRouting)
+	seed                  int64                    // Graine de l'aléa de l'exécution (Config.Seed), nulle si aucun aléa n'a été utilisé
+	prepared              bool                     // Prompts des agents déjà préparés (décomposition, reformulation) par une passe précédente
+	progress              *progressTracker         // Suivi de l'avancement (Config.Progress)
+	writers               []io.Writer              // Destination de la réponse de chaque agent (RunSocietyToWriters)
+	events                chan<- AgentEvent        // Destination des réponses des agents dès leur arrivée (RunSocietyStream)
+	caller                context.Context          // Contexte de l'appelant, avant application de Config.Timeout
+	synthModel            AIModel                  // Modèle de synthèse transmis à RunSocietyWithSynthesis
+	phaseMu               sync.Mutex               // Sérialise les appels à Config.OnPhase
+	phaseStarts           map[string]time.Time     // Début de chaque phase collaborative commencée
+	phaseDurations        map[string]time.Duration // Durée de chaque phase collaborative terminée
+	startedAt             time.Time                // Début de l'exécution
 }
 
 // Config contient la configuration pour une société
@@ -53,16 +208,314 @@ type Config struct {
 	MultiModel bool
 	// Collaborative indique si les agents travaillent en mode collaboratif
 	Collaborative bool
+	// Language force la langue des instructions envoyées aux modèles (français si vide)
+	Language Language
+	// AutoLanguage détecte la langue du prompt et l'applique aux instructions
+	// lorsque Language n'est pas renseigné
+	AutoLanguage bool
+	// IncludeSummary ajoute un résumé court (TL;DR) avant la réponse détaillée en mode collaboratif
+	IncludeSummary bool
+	// MaxAgents plafonne le nombre d'agents autorisé (0 signifie aucune limite)
+	MaxAgents int
+	// PhaseTemperatures ajuste la température de chaque phase collaborative
+	// pour les modèles implémentant ConfigurableAIModel ou ParameterizedAIModel
+	PhaseTemperatures PhaseTemperatures
+	// DisableTemperatureSpread conserve la température par défaut des modèles en modes standard et synthèse,
+	// au lieu de répartir les températures des agents entre DefaultAgentTemperatureMin et
+	// DefaultAgentTemperatureMax pour diversifier les perspectives (modèles implémentant ConfigurableAIModel
+	// ou ParameterizedAIModel)
+	DisableTemperatureSpread bool
+	// Audience adapte la réponse finale et la synthèse au public visé (ex: "dirigeants", "ingénieurs"),
+	// sans modifier les phases d'exploration
+	Audience string
+	// StructuredOutput demande une réponse finale collaborative en markdown,
+	// avec une section par dimension explorée
+	StructuredOutput bool
+	// AllowPartialResults tolère l'échec de certains agents : par défaut, l'exécution échoue dès qu'un agent
+	// échoue. Activé, les agents en échec sont écartés, conservés dans SocietyResult.Agents avec leur erreur
+	// et signalés dans la réponse, et les réponses des autres agents sont collectées ou synthétisées ;
+	// l'exécution n'échoue alors que si aucun agent n'aboutit (modes standard et synthèse).
+	AllowPartialResults bool
+	// FailFastThreshold interrompt la société dès que ce nombre d'agents échouent
+	// avec un message d'erreur identique (0 désactive la détection)
+	FailFastThreshold int
+	// SynthesisModel est le modèle de synthèse utilisé par ModeSynthesis (RunDetailed, RunWithRetry)
+	SynthesisModel AIModel `json:"-"`
+	// RetryBackoff est le délai d'attente initial entre deux tentatives de RunWithRetry
+	RetryBackoff time.Duration
+	// Integrator combine les analyses en mode collaboratif (PromptIntegrator si nil)
+	Integrator Integrator `json:"-"`
+	// BlockedResponsePolicy détermine le traitement des réponses bloquées (BlockedResponseFail par défaut)
+	BlockedResponsePolicy BlockedResponsePolicy
+	// BlockedPlaceholder remplace les réponses bloquées avec BlockedResponsePlaceholder
+	// (DefaultBlockedPlaceholder si vide)
+	BlockedPlaceholder string
+	// MaxPromptChars compresse tout prompt dépassant ce nombre de caractères avant l'envoi (0 désactive)
+	MaxPromptChars int
+	// PromptCompressor réalise la compression (ModelCompressor avec le modèle destinataire si nil)
+	PromptCompressor PromptCompressor `json:"-"`
+	// PhaseGate est appelé après chaque phase collaborative avec l'artefact produit
+	// (analyse initiale, analyse de chaque dimension, analyse intégrée, réponse finale).
+	// La valeur edited remplace l'artefact ; proceed=false interrompt l'exécution avec ErrPipelineAborted.
+	PhaseGate func(phase string, artifact string) (edited string, proceed bool, err error) `json:"-"`
+	// OnPhase est notifié du début et de la fin de chaque phase collaborative (PhaseStarted, PhaseCompleted)
+	// et de chaque agent terminé pendant l'exploration des dimensions. Purement informatif, il n'est
+	// jamais appelé simultanément depuis plusieurs goroutines ; la fin d'une phase en échec n'est pas signalée.
+	OnPhase func(phase string, detail string) `json:"-"`
+	// Similarity mesure la ressemblance entre deux textes (JaccardSimilarity si nil)
+	Similarity Similarity `json:"-"`
+	// AdaptiveSynthesisLength adapte la longueur de la synthèse à l'accord entre les agents
+	AdaptiveSynthesisLength bool
+	// HighAgreementThreshold est la similarité moyenne au-delà de laquelle la synthèse est courte
+	// (DefaultHighAgreementThreshold si 0)
+	HighAgreementThreshold float64
+	// LowAgreementThreshold est la similarité moyenne en deçà de laquelle la synthèse est détaillée
+	// (DefaultLowAgreementThreshold si 0)
+	LowAgreementThreshold float64
+	// ComputeAgreementStats calcule après l'exécution la similarité entre les réponses des agents
+	// regroupées par modèle (voir SocietyResult.Agreement et Similarity)
+	ComputeAgreementStats bool
+	// ExtractiveSynthesis demande au modèle de synthèse de composer la réponse uniquement à partir
+	// des affirmations des agents, sans ajouter d'information extérieure
+	ExtractiveSynthesis bool
+	// VerifyExtractiveSynthesis vérifie ensuite chaque phrase de la synthèse extractive et rapporte
+	// celles qu'aucune réponse d'agent n'appuie dans SocietyResult.UnsupportedStatements
+	VerifyExtractiveSynthesis bool
+	// ExtractiveSupportThreshold est la part minimale des mots d'une phrase retrouvés chez un même agent
+	// (DefaultExtractiveSupportThreshold si 0)
+	ExtractiveSupportThreshold float64
+	// StopCondition est évaluée en mode standard à chaque fin d'agent avec les résultats déjà obtenus,
+	// dans leur ordre d'arrivée. Lorsqu'elle retourne true, les agents restants sont annulés
+	// via leur contexte et écartés, puis la collecte ou la synthèse se poursuit.
+	StopCondition func(partial []AgentResult) bool `json:"-"`
+	// IncludeInsightsInFinal transmet aussi les analyses des dimensions (tronquées) à la génération
+	// de la réponse finale, en plus de l'analyse intégrée (mode collaboratif)
+	IncludeInsightsInFinal bool
+	// Timeout est le délai unique de l'exécution entière. Lorsqu'il est défini, il remplace les délais
+	// internes de chaque phase (DefaultAgentTimeout pour les agents, DefaultExplorationTimeout pour
+	// l'exploration) ; les délais propres à une phase (DimensionTimeouts) restent appliqués mais sont
+	// plafonnés par lui. Lorsqu'il est nul, l'échéance du contexte de l'appelant remplace les délais
+	// internes ; ceux-ci ne s'appliquent qu'à défaut d'échéance.
+	// Son expiration est rapportée par une TimeoutError. Une valeur négative équivaut à DisableTimeout.
+	Timeout time.Duration
+	// DisableTimeout supprime tous les délais internes (agents, exploration, DimensionTimeouts et Timeout) :
+	// l'exécution se poursuit jusqu'à ce que tous les agents aient terminé ou que le contexte de l'appelant
+	// soit annulé. L'appelant doit alors fournir lui-même un contexte avec échéance s'il en souhaite une.
+	DisableTimeout bool
+	// DimensionTimeouts accorde à l'exploration de certaines dimensions un délai propre
+	// (DefaultExplorationTimeout pour les dimensions absentes)
+	DimensionTimeouts map[string]time.Duration
+	// ExplorationWaves répartit l'exploration des dimensions en vagues successives, chaque vague
+	// recevant les analyses des précédentes (1 par défaut : toutes les dimensions en parallèle)
+	ExplorationWaves int
+	// SmartRouting attribue à chaque dimension le modèle dont les capacités (CapabilityReporter)
+	// lui correspondent le mieux, au lieu de la répartition modulo (mode collaboratif, MultiModel)
+	SmartRouting bool
+	// IncludeAgentPromptsInSynthesis présente au modèle de synthèse la question posée à chaque agent
+	// (tronquée) en plus de sa réponse
+	IncludeAgentPromptsInSynthesis bool
+	// MaxSynthesisInputTokens limite la taille des réponses transmises au modèle de synthèse :
+	// au-delà, des agents sont écartés selon SynthesisDropPolicy (voir SocietyResult.DroppedAgents)
+	MaxSynthesisInputTokens int
+	// SynthesisDropPolicy choisit les agents écartés de la synthèse (DropMostRedundant par défaut)
+	SynthesisDropPolicy SynthesisDropPolicy
+	// TokenCounter compte les tokens d'un texte (ApproximateTokenCounter si nil)
+	TokenCounter TokenCounter `json:"-"`
+	// SynthesisCandidates fait générer plusieurs synthèses candidates, à des températures différentes,
+	// parmi lesquelles la meilleure est retenue (1 ou 0 : une seule synthèse)
+	SynthesisCandidates int
+	// SynthesisJudge choisit la meilleure synthèse candidate (le modèle de synthèse si nil)
+	SynthesisJudge AIModel `json:"-"`
+	// CiteAgents demande au modèle de synthèse d'indiquer après chaque affirmation les agents qui la soutiennent,
+	// au format « [A1,A3] » : la lettre A suivie du numéro de l'agent (AgentID+1), séparés par des virgules
+	CiteAgents bool
+	// Synthesizer remplace la synthèse par le modèle de synthèse en mode synthèse ; le modèle de synthèse
+	// devient alors facultatif et les options de synthèse de la configuration ne s'appliquent plus
+	// (voir ModelSynthesizer)
+	Synthesizer Synthesizer `json:"-"`
+	// SynthesisStrategy choisit entre une synthèse rédigée (SynthesisProse, par défaut) et un vote
+	// faisant ressortir la position majoritaire et les désaccords (SynthesisVoting)
+	SynthesisStrategy SynthesisStrategy
+	// SynthesisBias oriente la synthèse vers les réponses les plus récentes ou les plus détaillées
+	SynthesisBias SynthesisBias
+	// InitialAnalysis fournit une analyse initiale déjà calculée (par exemple lors d'une question précédente
+	// sur le même sujet) : l'appel d'analyse initiale est alors omis. Ignoré hors du mode collaboratif.
+	InitialAnalysis string
+	// Dimensions remplace DefaultDimensions en mode collaboratif : chaque agent explore la dimension
+	// d'indice ID % len(Dimensions), la liste étant d'abord limitée au nombre d'agents
+	Dimensions []string
+	// ShuffleDimensions mélange les dimensions avant leur limitation au nombre d'agents et leur attribution,
+	// pour varier les explorations d'une exécution à l'autre (reproductible avec Seed)
+	ShuffleDimensions bool
+	// Seed initialise l'aléa introduit par la bibliothèque (ShuffleDimensions) : deux exécutions de même
+	// Seed font les mêmes choix. Avec 0, une graine est tirée à chaque exécution et rapportée par ResolvedConfig.
+	Seed int64
+	// Perspectives remplace DefaultPerspectives : chaque agent reçoit la perspective d'indice
+	// ID % len(Perspectives) en préfixe de son prompt, qui doit donc se terminer par un séparateur (ex: ": ")
+	Perspectives []string
+	// RepeatsPerPerspective attribue chaque perspective à ce nombre d'agents consécutifs, pour étudier
+	// la variance au sein d'une même perspective. AgentCount doit alors valoir
+	// RepeatsPerPerspective fois le nombre de perspectives (voir SocietyResult.ByPerspective)
+	RepeatsPerPerspective int
+	// DiversifyBeyondPerspectives demande aux agents qui réutilisent une perspective déjà attribuée
+	// (au-delà du premier cycle de perspectives) de proposer un angle différent (mode standard)
+	DiversifyBeyondPerspectives bool
+	// RephraseQuestions fait reformuler la question différemment pour chaque agent avant qu'il n'y réponde,
+	// en plus de sa perspective (modes standard et synthèse, sans effet avec DecomposePrompt).
+	// Chaque exécution reformule la question, sauf si RephraseCache est défini.
+	RephraseQuestions bool
+	// RephraseCache conserve les reformulations de RephraseQuestions entre les exécutions qui le partagent
+	// (désactivé si nil) ; EstimateModelCalls compte toutefois toutes les reformulations
+	RephraseCache *RephraseCache `json:"-"`
+	// Scratchpad met à disposition des modèles, en mode standard, un tableau de notes partagé
+	// (voir ScratchpadFromContext)
+	Scratchpad bool
+	// DecomposePrompt fait découper le prompt en une sous-question par agent par le modèle du premier agent,
+	// au lieu des perspectives (modes standard et synthèse, repli sur les perspectives en cas d'échec)
+	DecomposePrompt bool
+	// CoordinatorModel produit en mode standard une réponse coordonnée à partir des réponses des agents,
+	// avec un tour de questions aux agents si nécessaire
+	CoordinatorModel AIModel `json:"-"`
+	// UseCoordinator confie la coordination au dernier des modèles fournis, sans CoordinatorModel dédié :
+	// la réponse coordonnée remplace alors la juxtaposition des réponses en mode standard.
+	// Ce modèle peut aussi être attribué à des agents. Ignoré lorsque CoordinatorModel est défini.
+	UseCoordinator bool
+	// Passes fait répondre les agents en plusieurs passes (modes standard et synthèse) : à partir de la deuxième,
+	// chaque agent reçoit les réponses de tous les agents à la passe précédente et affine la sienne ;
+	// seules les réponses de la dernière passe sont collectées (0 ou 1 : une seule passe indépendante)
+	Passes int
+	// Checkpointer enregistre l'état des exécutions en plusieurs tours (Passes, RunSocietyDebate) après chaque
+	// tour terminé : une exécution interrompue puis relancée avec la même configuration reprend après
+	// le dernier tour enregistré au lieu de recommencer (désactivé si nil)
+	Checkpointer Checkpointer `json:"-"`
+	// CheckpointKey identifie l'exécution auprès du Checkpointer (par défaut, une empreinte du prompt,
+	// des modèles et de la configuration, comme pour Config.Cache)
+	CheckpointKey string
+	// AgentWeights donne le poids de chaque agent dans la synthèse, dans l'ordre des agents (1 pour un poids
+	// absent ou nul) : le modèle de synthèse accorde davantage d'importance aux agents de poids élevé,
+	// par exemple un modèle puissant de poids 3 face à des modèles légers de poids 1
+	AgentWeights []float64
+	// TreeSynthesisThreshold fait synthétiser hiérarchiquement les réponses lorsque leur nombre le dépasse
+	// (voir SynthesizeTree), pour ne pas saturer la fenêtre de contexte du modèle de synthèse (0 : désactivé)
+	TreeSynthesisThreshold int
+	// TreeSynthesisBranching est le nombre de réponses synthétisées ensemble à chaque niveau
+	// (DefaultTreeSynthesisBranching si inférieur à 2)
+	TreeSynthesisBranching int
+	// AgentMaxWords limite la longueur de la réponse de chaque agent, par une consigne de concision
+	// et une limite de tokens (0 : illimitée)
+	AgentMaxWords int
+	// AgentMaxWordsByAgent remplace AgentMaxWords pour certains agents (indexé par agent, 0 : AgentMaxWords)
+	AgentMaxWordsByAgent []int
+	// MaxConversationTurns limite, au début de chaque exécution, l'historique des modèles qui implémentent
+	// ConversationTrimmer (0 : historique conservé tel quel)
+	MaxConversationTurns int
+	// FilterPromptEchoes écarte, en mode standard et synthèse, les réponses trop similaires
+	// au prompt de l'agent (voir Similarity), qui reprennent la question au lieu d'y répondre
+	FilterPromptEchoes bool
+	// EchoThreshold est la similarité à partir de laquelle une réponse est une reprise du prompt
+	// (DefaultEchoThreshold si 0)
+	EchoThreshold float64
+	// Validator vérifie la réponse finale avant qu'elle ne soit retournée
+	Validator Validator `json:"-"`
+	// Repair corrige une réponse rejetée par Validator (une seule tentative, pas de correction si nil)
+	Repair Repair `json:"-"`
+	// Progress reçoit les événements d'avancement de l'exécution ; les événements sont abandonnés
+	// plutôt que de bloquer l'exécution si le canal n'est pas prêt
+	Progress chan<- ProgressEvent `json:"-"`
+	// SanitizePrompt encadre le prompt de l'utilisateur de délimiteurs, dans tous les prompts internes,
+	// avec la consigne de le traiter comme une donnée à analyser et non comme des instructions,
+	// pour les services qui reçoivent des prompts non fiables
+	SanitizePrompt bool
+	// PromptSanitizer transforme le prompt de l'utilisateur avant son insertion dans les prompts internes,
+	// avant l'encadrement éventuel de SanitizePrompt
+	PromptSanitizer func(prompt string) string `json:"-"`
+	// Cache conserve les résultats de RunDetailed pour servir les exécutions identiques (désactivé si nil)
+	Cache *ResultCache `json:"-"`
+	// PrimaryAgentID désigne l'agent (indice de 0 à AgentCount-1) qui conduit les phases à agent unique :
+	// analyse initiale, intégration, réponse finale et résumé en mode collaboratif (0 par défaut)
+	PrimaryAgentID int
+	// Mode est le mode de fonctionnement utilisé par RunSocietyOverCorpus et RunSocietyResult (ModeStandard si vide)
+	Mode Mode
+	// CorpusConcurrency est le nombre de prompts traités simultanément par RunSocietyOverCorpus
+	// (DefaultCorpusConcurrency si 0)
+	CorpusConcurrency int
+	// RunID identifie l'exécution pour corréler journaux et métriques (généré si vide,
+	// voir RunIDFromContext)
+	RunID string
+	// Tags étiquette l'exécution (par exemple {"tenant": "acme"}) : les étiquettes sont transmises
+	// au MetricsRecorder, aux événements d'avancement, au contexte (TagsFromContext) et au résultat
+	Tags map[string]string
+	// Metrics reçoit les métriques de chaque exécution
+	Metrics MetricsRecorder `json:"-"`
+	// CleanScaffolding retire de la réponse finale les fragments des prompts internes et les
+	// méta-commentaires (voir ScaffoldingPhrases)
+	CleanScaffolding bool
+	// ScaffoldingPhrases complète ScaffoldingPhrases pour cette exécution
+	ScaffoldingPhrases []string
+	// Scheduler contrôle l'exécution des agents (ParallelScheduler si nil)
+	Scheduler Scheduler `json:"-"`
+	// MaxConcurrency borne le nombre d'appels d'agents simultanés, en mode standard comme pendant
+	// l'exploration collaborative, pour ménager les API limitées en débit (0 = illimité).
+	// Un Scheduler explicite prime sur cette limite.
+	MaxConcurrency int
+	// ExploreConcurrency borne le nombre d'explorations simultanées en mode collaboratif, au sein de chaque
+	// vague ; il prime sur Scheduler pour cette phase (0 conserve l'ordonnanceur configuré)
+	ExploreConcurrency int
 }
 
-// NewConfig crée une nouvelle configuration avec des valeurs par défaut
-func NewConfig(prompt string, agentCount int) *Config {
-	return &Config{
+// NewConfig crée une nouvelle configuration avec des valeurs par défaut, puis applique les options dans l'ordre
+func NewConfig(prompt string, agentCount int, opts ...Option) *Config {
+	config := &Config{
 		Prompt:        prompt,
 		AgentCount:    agentCount,
 		MultiModel:    true,
 		Collaborative: false,
 	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// timeoutDisabled indique si les délais internes de l'exécution sont désactivés
+func (c *Config) timeoutDisabled() bool {
+	return c.DisableTimeout || c.Timeout < 0
+}
+
+// Validate vérifie la cohérence de la configuration avant le lancement des agents
+func (c *Config) Validate() error {
+	if c.AgentCount <= 0 {
+		return ErrInvalidAgentCount
+	}
+	if c.MaxAgents > 0 && c.AgentCount > c.MaxAgents {
+		return ErrTooManyAgents
+	}
+	if c.PrimaryAgentID < 0 || (c.PrimaryAgentID > 0 && c.PrimaryAgentID >= c.AgentCount) {
+		return fmt.Errorf("%w: %d (AgentCount = %d)", ErrInvalidPrimaryAgent, c.PrimaryAgentID, c.AgentCount)
+	}
+	if c.RepeatsPerPerspective > 0 && c.AgentCount != c.RepeatsPerPerspective*len(c.perspectives()) {
+		return fmt.Errorf("%w: %d agent(s) attendu(s), %d configuré(s)", ErrPerspectiveRepeats,
+			c.RepeatsPerPerspective*len(c.perspectives()), c.AgentCount)
+	}
+	for i, weight := range c.AgentWeights {
+		if weight < 0 {
+			return fmt.Errorf("%w: agent %d, poids %g", ErrInvalidAgentWeight, i, weight)
+		}
+	}
+	return nil
+}
+
+// validateModels vérifie qu'au moins un modèle est fourni et qu'aucun n'est nil
+func validateModels(models []AIModel) error {
+	if len(models) == 0 {
+		return ErrNoModelsSpecified
+	}
+	for i, model := range models {
+		if model == nil {
+			return fmt.Errorf("%w (modèle %d)", ErrNilModel, i)
+		}
+	}
+	return nil
 }
 
 // Error est un type d'erreur personnalisé
@@ -80,6 +533,74 @@ func (e *Error) Error() string {
 	return e.Message
 }
 
+// AgentError associe une erreur à l'agent qui l'a produite
+type AgentError struct {
+	AgentID   int    // Identifiant de l'agent en échec
+	Dimension string // Dimension explorée par l'agent (mode collaboratif uniquement)
+	Err       error  // Erreur d'origine
+}
+
+// Error implémente l'interface error
+func (e *AgentError) Error() string {
+	if e.Dimension != "" {
+		return fmt.Sprintf("agent %d (dimension: %s): %v", e.AgentID, e.Dimension, e.Err)
+	}
+	return fmt.Sprintf("agent %d: %v", e.AgentID, e.Err)
+}
+
+// Unwrap retourne l'erreur d'origine
+func (e *AgentError) Unwrap() error {
+	return e.Err
+}
+
+// BlockedError est une interface optionnelle qu'une erreur de modèle peut implémenter
+// pour signaler que la réponse a été bloquée (filtre de sécurité, absence de candidats...)
+type BlockedError interface {
+	error
+	// Blocked indique si la réponse a été bloquée par le fournisseur
+	Blocked() bool
+}
+
+// isBlocked indique si une erreur signale une réponse bloquée
+func isBlocked(err error) bool {
+	var blocked BlockedError
+	return errors.As(err, &blocked) && blocked.Blocked()
+}
+
+// BlockedResponsePolicy détermine le traitement des agents dont la réponse a été bloquée
+type BlockedResponsePolicy int
+
+// Politiques de traitement des réponses bloquées
+const (
+	// BlockedResponseFail traite la réponse bloquée comme une erreur de l'agent (comportement par défaut)
+	BlockedResponseFail BlockedResponsePolicy = iota
+	// BlockedResponseSkip écarte l'agent des résultats
+	BlockedResponseSkip
+	// BlockedResponsePlaceholder remplace la réponse de l'agent par Config.BlockedPlaceholder
+	BlockedResponsePlaceholder
+)
+
+// DefaultBlockedPlaceholder est le texte substitué à une réponse bloquée avec BlockedResponsePlaceholder
+const DefaultBlockedPlaceholder = "[Réponse bloquée par le fournisseur du modèle]"
+
+// TimeoutError est retournée lorsque seul le délai interne de la société a expiré,
+// sans qu'aucun modèle n'ait rapporté d'erreur propre. Elle transporte les résultats
+// partiels obtenus avant l'expiration et satisfait errors.Is(err, ErrSocietyTimeout).
+type TimeoutError struct {
+	Phase   string         // Phase interrompue par l'expiration du délai
+	Partial *SocietyResult // Résultats obtenus avant l'expiration
+}
+
+// Error implémente l'interface error
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s (phase: %s)", ErrSocietyTimeout.Error(), e.Phase)
+}
+
+// Unwrap permet de reconnaître l'erreur via ErrSocietyTimeout ou context.DeadlineExceeded
+func (e *TimeoutError) Unwrap() []error {
+	return []error{ErrSocietyTimeout, context.DeadlineExceeded}
+}
+
 // Erreurs communes
 var (
 	// ErrModelNotSupported est retourné quand un modèle n'est pas supporté
@@ -90,4 +611,30 @@ var (
 	ErrInvalidAgentCount = NewError("le nombre d'agents doit être positif")
 	// ErrNoModelsSpecified est retourné quand aucun modèle n'est spécifié
 	ErrNoModelsSpecified = NewError("au moins un modèle AI doit être spécifié")
+	// ErrAgentNotScheduled est retourné pour un agent que l'ordonnanceur n'a pas exécuté
+	ErrAgentNotScheduled = NewError("l'agent n'a pas été exécuté par l'ordonnanceur")
+	// ErrSocietyTimeout est retourné lorsque le délai interne de la société expire
+	ErrSocietyTimeout = NewError("délai d'exécution de la société dépassé")
+	// ErrNoSynthesisModel est retourné quand le mode synthèse est demandé sans modèle de synthèse
+	ErrNoSynthesisModel = NewError("le modèle de synthèse ne peut pas être nil")
+	// ErrPipelineAborted est retourné quand Config.PhaseGate interrompt l'exécution
+	ErrPipelineAborted = NewError("exécution interrompue par la validation d'une phase")
+	// ErrTooManyAgents est retourné quand le nombre d'agents dépasse Config.MaxAgents
+	ErrTooManyAgents = NewError("le nombre d'agents dépasse la limite autorisée")
+	// ErrInvalidAnswer est retourné quand la réponse finale est rejetée par Config.Validator
+	ErrInvalidAnswer = NewError("la réponse finale ne respecte pas les contraintes de validation")
+	// ErrNoDuelWinner est retourné quand le verdict du juge de DuelModels ou d'ExplainValueAdd ne désigne aucun gagnant
+	ErrNoDuelWinner = NewError("le juge n'a désigné aucun gagnant")
+	// ErrNilModel est retourné quand un modèle fourni à la société est nil
+	ErrNilModel = NewError("le modèle d'IA ne peut pas être nil")
+	// ErrNotEnoughWriters est retourné par RunSocietyToWriters quand il manque un writer pour un agent
+	ErrNotEnoughWriters = NewError("un writer doit être fourni pour chaque agent")
+	// ErrInvalidPrimaryAgent est retourné quand Config.PrimaryAgentID ne désigne aucun agent
+	ErrInvalidPrimaryAgent = NewError("l'agent principal ne correspond à aucun agent de la société")
+	// ErrPerspectiveRepeats est retourné quand AgentCount ne correspond pas à Config.RepeatsPerPerspective
+	ErrPerspectiveRepeats = NewError("le nombre d'agents doit valoir RepeatsPerPerspective fois le nombre de perspectives")
+	// ErrRunnerClosed est retourné par Runner.Run après l'appel à Shutdown
+	ErrRunnerClosed = NewError("le runner est arrêté")
+	// ErrInvalidAgentWeight est retourné quand un poids de Config.AgentWeights est négatif
+	ErrInvalidAgentWeight = NewError("le poids d'un agent ne peut pas être négatif")
 )
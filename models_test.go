@@ -0,0 +1,53 @@
+package societyai_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/benoitpetit/societyai"
+)
+
+// paramsModel n'implémente que ProcessWithParams et mémorise les paramètres reçus
+type paramsModel struct {
+	mu     sync.Mutex
+	params []societyai.ModelParams
+}
+
+func (m *paramsModel) Name() string {
+	return "params"
+}
+
+func (m *paramsModel) Process(ctx context.Context, prompt string) (string, error) {
+	return m.ProcessWithParams(ctx, prompt, societyai.ModelParams{})
+}
+
+func (m *paramsModel) ProcessWithParams(ctx context.Context, prompt string, params societyai.ModelParams) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.params = append(m.params, params)
+	return "réponse", nil
+}
+
+func TestProcessWithParamsReceivesTemperatureSpread(t *testing.T) {
+	model := &paramsModel{}
+	config := societyai.NewConfig("Comment prioriser une feuille de route ?", 3)
+	if _, err := societyai.RunSocietyDetailed(context.Background(), config, []societyai.AIModel{model}); err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+
+	if len(model.params) != config.AgentCount {
+		t.Fatalf("%d appel(s) paramétré(s), %d attendu(s)", len(model.params), config.AgentCount)
+	}
+	temperatures := make(map[float64]bool)
+	for _, params := range model.params {
+		if params.Temperature < societyai.DefaultAgentTemperatureMin || params.Temperature > societyai.DefaultAgentTemperatureMax {
+			t.Errorf("température %v hors de [%v, %v]", params.Temperature,
+				societyai.DefaultAgentTemperatureMin, societyai.DefaultAgentTemperatureMax)
+		}
+		temperatures[params.Temperature] = true
+	}
+	if len(temperatures) != config.AgentCount {
+		t.Errorf("%d température(s) distincte(s) pour %d agents", len(temperatures), config.AgentCount)
+	}
+}
@@ -0,0 +1,280 @@
+// Package openaiadapter fournit un modèle SocietyAI pour les API compatibles avec le protocole
+// OpenAI /v1/chat/completions : OpenAI, mais aussi Ollama, LM Studio, Together ou Groq,
+// en indiquant l'URL de base du fournisseur.
+//
+// Chaque appel est indépendant (aucun historique de conversation n'est conservé) : un même
+// modèle peut être partagé par plusieurs agents exécutés en parallèle. LastUsage et
+// LastFinishReason ne décrivent toutefois que le dernier appel du modèle : pour une
+// consommation et des motifs d'arrêt exacts, attribuez un ChatModel distinct à chaque agent.
+package openaiadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benoitpetit/societyai"
+)
+
+// DefaultBaseURL est l'URL de base de l'API OpenAI, utilisée lorsqu'aucune URL n'est fournie
+const DefaultBaseURL = "https://api.openai.com/v1"
+
+// Valeurs par défaut des relances d'un ChatModel
+const (
+	// DefaultMaxRetries est le nombre de relances après un échec temporaire
+	DefaultMaxRetries = 3
+	// DefaultRetryDelay est le délai d'attente initial entre deux tentatives, doublé à chaque relance
+	DefaultRetryDelay = time.Second
+)
+
+// ChatModel implémente l'interface societyai.AIModel pour une API compatible OpenAI,
+// ainsi que societyai.ConfigurableAIModel, societyai.FinishReporter et societyai.UsageReporter
+type ChatModel struct {
+	APIKey     string        // Clé d'API, envoyée en en-tête Authorization (omise si vide, ex: Ollama)
+	Model      string        // Nom du modèle demandé au fournisseur
+	BaseURL    string        // URL de base de l'API, sans /chat/completions
+	HTTPClient *http.Client  // Client HTTP utilisé pour les requêtes
+	MaxRetries int           // Nombre de relances après un échec temporaire (réseau, 429, 5xx)
+	RetryDelay time.Duration // Délai d'attente initial entre deux tentatives, doublé à chaque relance
+
+	mu               sync.Mutex
+	lastFinishReason string
+	lastUsage        societyai.Usage
+}
+
+// NewChatModel crée un modèle pour l'API compatible OpenAI située à baseURL (DefaultBaseURL si vide),
+// par exemple "http://localhost:11434/v1" pour Ollama. Les échecs temporaires sont relancés
+// DefaultMaxRetries fois ; le modèle retourné est un *ChatModel pour ajuster ces réglages.
+func NewChatModel(apiKey, model, baseURL string) societyai.AIModel {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &ChatModel{
+		APIKey:     apiKey,
+		Model:      model,
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 120 * time.Second},
+		MaxRetries: DefaultMaxRetries,
+		RetryDelay: DefaultRetryDelay,
+	}
+}
+
+// APIError est une erreur retournée par l'API, avec le message extrait du corps de la réponse.
+// Elle implémente societyai.RetryableError : seules les erreurs 429 et 5xx peuvent être relancées.
+type APIError struct {
+	StatusCode int    // Code HTTP de la réponse
+	Type       string // Type d'erreur rapporté par le fournisseur
+	Message    string // Message d'erreur rapporté par le fournisseur, ou corps brut de la réponse
+}
+
+// Error implémente l'interface error
+func (e *APIError) Error() string {
+	if e.Type != "" {
+		return fmt.Sprintf("erreur API %d (%s): %s", e.StatusCode, e.Type, e.Message)
+	}
+	return fmt.Sprintf("erreur API %d: %s", e.StatusCode, e.Message)
+}
+
+// Retryable implémente l'interface societyai.RetryableError
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// BlockedError signale une réponse bloquée par le filtre de contenu du fournisseur
+// (implémente l'interface societyai.BlockedError)
+type BlockedError struct {
+	Reason string
+}
+
+// Error implémente l'interface error
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("réponse bloquée par le fournisseur: %s", e.Reason)
+}
+
+// Blocked implémente l'interface societyai.BlockedError
+func (e *BlockedError) Blocked() bool {
+	return true
+}
+
+// Retryable implémente l'interface societyai.RetryableError : le même prompt serait de nouveau bloqué
+func (e *BlockedError) Retryable() bool {
+	return false
+}
+
+// chatMessage est un message de l'API chat/completions
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatRequest est le corps d'une requête chat/completions
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature,omitempty"`
+	TopP        float64       `json:"top_p,omitempty"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+}
+
+// chatResponse est le corps d'une réponse chat/completions
+type chatResponse struct {
+	Choices []struct {
+		Message      chatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// Name implémente l'interface societyai.AIModel
+func (m *ChatModel) Name() string {
+	return m.Model
+}
+
+// Process envoie le prompt à l'API et retourne la réponse (implémente l'interface societyai.AIModel)
+func (m *ChatModel) Process(ctx context.Context, prompt string) (string, error) {
+	return m.ProcessWithOptions(ctx, prompt, societyai.ProcessOptions{})
+}
+
+// ProcessWithOptions envoie le prompt à l'API avec les paramètres de génération fournis
+// (implémente l'interface societyai.ConfigurableAIModel) ; les paramètres nuls reprennent
+// les valeurs par défaut du fournisseur
+func (m *ChatModel) ProcessWithOptions(ctx context.Context, prompt string, opts societyai.ProcessOptions) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:       m.Model,
+		Messages:    []chatMessage{{Role: "user", Content: prompt}},
+		Temperature: opts.Temperature,
+		TopP:        opts.TopP,
+		MaxTokens:   opts.MaxTokens,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	delay := m.RetryDelay
+	if delay <= 0 {
+		delay = DefaultRetryDelay
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= m.MaxRetries; attempt++ {
+		if attempt > 0 {
+			// Attendre avant la tentative suivante sans ignorer l'annulation du contexte
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return "", fmt.Errorf("abandon après %d tentative(s): %w", attempt, lastErr)
+			}
+			delay *= 2
+		}
+
+		result, err := m.send(ctx, body)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		// Inutile de relancer une erreur définitive ou si le contexte de l'appelant est terminé
+		if !societyai.IsRetryable(err) || ctx.Err() != nil {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("échec après %d tentative(s): %w", m.MaxRetries+1, lastErr)
+}
+
+// send envoie une requête à l'API et décode sa réponse
+func (m *ChatModel) send(ctx context.Context, body []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", societyai.NonRetryable(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.APIKey)
+	}
+
+	client := m.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", apiError(resp.StatusCode, data)
+	}
+
+	var result chatResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("réponse illisible: %w", err)
+	}
+	if len(result.Choices) == 0 {
+		return "", errors.New("réponse vide reçue de l'API")
+	}
+
+	choice := result.Choices[0]
+	m.mu.Lock()
+	m.lastFinishReason = choice.FinishReason
+	m.lastUsage = societyai.Usage{
+		PromptTokens:     result.Usage.PromptTokens,
+		CompletionTokens: result.Usage.CompletionTokens,
+	}
+	m.mu.Unlock()
+
+	if choice.FinishReason == "content_filter" {
+		return "", &BlockedError{Reason: choice.FinishReason}
+	}
+	return choice.Message.Content, nil
+}
+
+// apiError extrait le message d'erreur du corps d'une réponse en échec ({"error": {"message": ...}}),
+// en se repliant sur le corps brut lorsqu'il ne suit pas ce format (ou sur le libellé du code HTTP s'il est vide)
+func apiError(status int, body []byte) error {
+	var payload struct {
+		Error struct {
+			Message string `json:"message"`
+			Type    string `json:"type"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Error.Message != "" {
+		return &APIError{StatusCode: status, Type: payload.Error.Type, Message: payload.Error.Message}
+	}
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return &APIError{StatusCode: status, Message: message}
+}
+
+// LastFinishReason implémente l'interface societyai.FinishReporter
+func (m *ChatModel) LastFinishReason() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastFinishReason
+}
+
+// LastUsage implémente l'interface societyai.UsageReporter
+func (m *ChatModel) LastUsage() societyai.Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastUsage
+}
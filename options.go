@@ -0,0 +1,27 @@
+package societyai
+
+import "time"
+
+// Option modifie une configuration créée par NewConfig
+type Option func(*Config)
+
+// WithMultiModel indique si plusieurs modèles peuvent être utilisés (activé par défaut)
+func WithMultiModel(enabled bool) Option {
+	return func(c *Config) {
+		c.MultiModel = enabled
+	}
+}
+
+// WithCollaborative indique si les agents travaillent en mode collaboratif
+func WithCollaborative(enabled bool) Option {
+	return func(c *Config) {
+		c.Collaborative = enabled
+	}
+}
+
+// WithTimeout définit le délai unique de l'exécution entière (voir Config.Timeout)
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.Timeout = timeout
+	}
+}
@@ -0,0 +1,88 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// passes retourne le nombre de passes des agents (Config.Passes), au moins une
+func (c *Config) passes() int {
+	if c == nil || c.Passes < 1 {
+		return 1
+	}
+	return c.Passes
+}
+
+// runPasses lance les agents pour chacune des passes configurées : après la première passe,
+// indépendante, le prompt de chaque agent est complété par les réponses de la passe
+// précédente pour qu'il affine sa réponse. Les résultats de la société sont ceux de la dernière passe.
+// Avec Config.Checkpointer, l'état est enregistré après chaque passe et l'exécution reprend après
+// la dernière passe enregistrée ; il est supprimé une fois toutes les passes terminées.
+func (s *SocietyGroup) runPasses(ctx context.Context) error {
+	passes := s.config.passes()
+	if passes == 1 {
+		return s.run(ctx)
+	}
+
+	key := s.checkpointKey("passes")
+	state, err := s.loadCheckpoint(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	// Conserver les prompts de la première passe, complétés à chaque passe par les réponses précédentes
+	var prompts []string
+	first := 2
+	if state.resumable(len(s.Agents), passes) && len(state.Prompts) == len(s.Agents) {
+		// Reprendre après la dernière passe enregistrée
+		s.restoreCheckpoint(state)
+		prompts = state.Prompts
+		first = state.Round + 1
+	} else {
+		if err := s.run(ctx); err != nil {
+			return err
+		}
+		prompts = make([]string, len(s.Agents))
+		for i, agent := range s.Agents {
+			prompts[i] = agent.Prompt
+		}
+		if err := s.saveCheckpoint(ctx, key, &roundState{Round: 1, Prompts: prompts}); err != nil {
+			return err
+		}
+	}
+
+	for pass := first; pass <= passes; pass++ {
+		shared := formatPassOutputs(s.activeResults())
+		for i, agent := range s.Agents {
+			agent.Prompt = buildPassPrompt(prompts[i], shared)
+		}
+
+		if err := s.run(ctx); err != nil {
+			return fmt.Errorf("passe %d: %w", pass, err)
+		}
+		if err := s.saveCheckpoint(ctx, key, &roundState{Round: pass, Prompts: prompts}); err != nil {
+			return err
+		}
+	}
+	return s.clearCheckpoint(ctx, key)
+}
+
+// formatPassOutputs présente les réponses des agents non écartés d'une passe
+func formatPassOutputs(results []AgentResult) string {
+	var text strings.Builder
+	for _, result := range results {
+		fmt.Fprintf(&text, "=== Agent %d ===\n%s\n\n", result.AgentID+1, result.Output)
+	}
+	return text.String()
+}
+
+// buildPassPrompt complète le prompt initial de l'agent par les réponses de la passe précédente,
+// dont l'agent doit tenir compte pour affiner sa propre réponse
+func buildPassPrompt(prompt, shared string) string {
+	return fmt.Sprintf(
+		"%s\n\nRéponses des agents à la passe précédente (dont la tienne):\n%s"+
+			"En tenant compte de ces réponses, complète et corrige ta propre réponse selon ta perspective. "+
+			"Retourne uniquement ta réponse affinée.",
+		prompt, shared)
+}
@@ -0,0 +1,45 @@
+package societyai_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/benoitpetit/societyai"
+	"github.com/benoitpetit/societyai/testmodel"
+)
+
+func TestPassesShareResponsesInPrompts(t *testing.T) {
+	model := testmodel.New("model", func(prompt string) (string, error) {
+		if strings.Contains(prompt, "passe précédente") {
+			return "réponse affinée", nil
+		}
+		return "première réponse", nil
+	})
+
+	config := societyai.NewConfig("Comment documenter une API ?", 2)
+	config.Passes = 2
+	result, err := societyai.RunSocietyDetailed(context.Background(), config, []societyai.AIModel{model})
+	if err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+
+	prompts := model.Prompts()
+	if len(prompts) != config.AgentCount*config.Passes {
+		t.Fatalf("%d prompt(s) envoyé(s), %d attendu(s)", len(prompts), config.AgentCount*config.Passes)
+	}
+	refined := 0
+	for _, prompt := range prompts {
+		if strings.Contains(prompt, "passe précédente") && strings.Contains(prompt, "première réponse") {
+			refined++
+		}
+	}
+	if refined != config.AgentCount {
+		t.Errorf("%d prompt(s) de deuxième passe avec les réponses précédentes, %d attendu(s)", refined, config.AgentCount)
+	}
+	for i, agent := range result.Agents {
+		if agent.Output != "réponse affinée" {
+			t.Errorf("Agents[%d].Output = %q, la réponse de la dernière passe est attendue", i, agent.Output)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package societyai
+
+import (
+	"sync"
+	"time"
+)
+
+// ProgressEvent décrit l'avancement d'une exécution, émis sur Config.Progress
+// à chaque appel de modèle terminé puis à la fin de l'exécution.
+//
+// EstimatedRemaining est une estimation linéaire : la durée moyenne des unités de travail
+// déjà terminées multipliée par le nombre d'unités restantes (voir EstimateModelCalls).
+// Elle ne tient compte ni du parallélisme ni des écarts de durée entre phases (une exploration
+// est souvent plus longue qu'une intégration) : très approximative au début de l'exécution,
+// elle s'affine à mesure que les phases se terminent.
+type ProgressEvent struct {
+	RunID              string            // Identifiant de l'exécution
+	Tags               map[string]string // Étiquettes de l'exécution (Config.Tags)
+	Phase              string            // Phase de l'unité de travail qui vient de se terminer
+	Completed          int               // Unités de travail terminées
+	Total              int               // Unités de travail prévues
+	Percent            float64           // Avancement, de 0 à 100
+	Elapsed            time.Duration     // Durée écoulée depuis le début de l'exécution
+	EstimatedRemaining time.Duration     // Estimation de la durée restante
+}
+
+// progressTracker suit l'avancement d'une exécution
+type progressTracker struct {
+	mu        sync.Mutex
+	start     time.Time
+	completed int
+	total     int
+}
+
+// startProgress commence le suivi de l'avancement si Config.Progress est défini
+func (s *SocietyGroup) startProgress(mode Mode) {
+	if s.config == nil || s.config.Progress == nil {
+		return
+	}
+	s.progress = &progressTracker{start: time.Now(), total: EstimateModelCalls(mode, s.config)}
+}
+
+// advance enregistre la fin d'une unité de travail de la phase indiquée et émet l'avancement
+func (s *SocietyGroup) advance(phase string) {
+	p := s.progress
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed++
+	if p.completed > p.total {
+		// Tours supplémentaires non prévus par l'estimation (précisions du coordinateur...)
+		p.total = p.completed
+	}
+	s.emitProgress(phase)
+}
+
+// finishProgress émet l'événement de fin d'exécution
+func (s *SocietyGroup) finishProgress(phase string) {
+	p := s.progress
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completed = p.total
+	s.emitProgress(phase)
+}
+
+// emitProgress envoie l'avancement courant sans bloquer l'exécution : un événement
+// est abandonné si le canal n'est pas prêt à le recevoir. Doit être appelée sous p.mu.
+func (s *SocietyGroup) emitProgress(phase string) {
+	p := s.progress
+	elapsed := time.Since(p.start)
+
+	event := ProgressEvent{
+		RunID:     s.runID,
+		Tags:      s.runTags(),
+		Phase:     phase,
+		Completed: p.completed,
+		Total:     p.total,
+		Elapsed:   elapsed,
+	}
+	if p.total > 0 {
+		event.Percent = float64(p.completed) * 100 / float64(p.total)
+	}
+	if p.completed > 0 && p.completed < p.total {
+		event.EstimatedRemaining = elapsed / time.Duration(p.completed) * time.Duration(p.total-p.completed)
+	}
+
+	select {
+	case s.config.Progress <- event:
+	default:
+	}
+}
@@ -0,0 +1,89 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// maxRephraseCacheEntries borne la taille d'un RephraseCache
+const maxRephraseCacheEntries = 1024
+
+// RephraseCache conserve les reformulations déjà obtenues (Config.RephraseCache), pour qu'une même
+// question ne soit pas reformulée à chaque exécution qui partage le cache. Les reformulations sont
+// indexées par le nom du modèle, l'agent et le prompt : les modèles qui partagent un cache doivent
+// donc porter des noms distincts. Au-delà de 1024 entrées, le cache repart de zéro.
+type RephraseCache struct {
+	mu      sync.Mutex
+	entries map[string]string
+}
+
+// NewRephraseCache crée un cache de reformulations vide
+func NewRephraseCache() *RephraseCache {
+	return &RephraseCache{entries: make(map[string]string)}
+}
+
+// get retourne la reformulation mémorisée pour key
+func (c *RephraseCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rephrased, ok := c.entries[key]
+	return rephrased, ok
+}
+
+// set mémorise la reformulation pour key
+func (c *RephraseCache) set(key, rephrased string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxRephraseCacheEntries {
+		// Cache plein : repartir de zéro plutôt que de croître indéfiniment
+		c.entries = make(map[string]string)
+	}
+	c.entries[key] = rephrased
+}
+
+// rephraseQuestions fait reformuler la question de base différemment pour chaque agent, en parallèle,
+// puis substitue la reformulation à la question dans le prompt de l'agent (perspective et consignes
+// conservées). Un agent dont la reformulation échoue garde la question d'origine.
+func (s *SocietyGroup) rephraseQuestions(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, agent := range s.Agents {
+		wg.Add(1)
+		go func(a *Agent) {
+			defer wg.Done()
+			rephrased, ok := s.rephrase(ctx, a)
+			if ok {
+				a.Prompt = strings.Replace(a.Prompt, s.config.userPrompt(), s.config.sanitizePrompt(rephrased), 1)
+			}
+		}(agent)
+	}
+	wg.Wait()
+}
+
+// rephrase retourne la reformulation de la question pour l'agent, depuis Config.RephraseCache si possible
+func (s *SocietyGroup) rephrase(ctx context.Context, a *Agent) (string, bool) {
+	cache := s.config.RephraseCache
+	key := fmt.Sprintf("%s\x00%d\x00%s", a.Model.Name(), a.ID, s.config.Prompt)
+	if cache != nil {
+		if cached, ok := cache.get(key); ok {
+			return cached, true
+		}
+	}
+
+	prompt := fmt.Sprintf(
+		"Reformule la question suivante d'une manière différente (variante n°%d), sans en changer le sens "+
+			"ni en retirer d'information. Retourne uniquement la question reformulée.\n\nQuestion: %s",
+		a.ID+1, s.config.userPrompt())
+	rephrased, err := s.callModel(ctx, a.Model, prompt, ProcessOptions{})
+	s.advance(PhaseRephrase)
+	rephrased = strings.TrimSpace(rephrased)
+	if err != nil || rephrased == "" {
+		return "", false
+	}
+
+	if cache != nil {
+		cache.set(key, rephrased)
+	}
+	return rephrased, true
+}
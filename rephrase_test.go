@@ -0,0 +1,42 @@
+package societyai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/benoitpetit/societyai"
+	"github.com/benoitpetit/societyai/testmodel"
+)
+
+func TestRephraseCacheIsOptIn(t *testing.T) {
+	model := testmodel.New("model", func(prompt string) (string, error) {
+		return "Question reformulée ?", nil
+	})
+	cache := societyai.NewRephraseCache()
+	run := func(cache *societyai.RephraseCache) int {
+		model.Reset()
+		config := societyai.NewConfig("Comment mesurer la dette technique ?", 3)
+		config.RephraseQuestions = true
+		config.RephraseCache = cache
+		if _, err := societyai.RunSocietyDetailed(context.Background(), config, []societyai.AIModel{model}); err != nil {
+			t.Fatalf("exécution en échec: %v", err)
+		}
+		return model.Calls()
+	}
+
+	// Sans cache, chaque exécution reformule la question : 3 reformulations et 3 réponses
+	if calls := run(nil); calls != 6 {
+		t.Errorf("%d appel(s) sans cache, 6 attendus", calls)
+	}
+	if calls := run(nil); calls != 6 {
+		t.Errorf("%d appel(s) à la deuxième exécution sans cache, 6 attendus", calls)
+	}
+
+	// Avec un cache partagé, la deuxième exécution réutilise les reformulations
+	if calls := run(cache); calls != 6 {
+		t.Errorf("%d appel(s) à la première exécution avec cache, 6 attendus", calls)
+	}
+	if calls := run(cache); calls != 3 {
+		t.Errorf("%d appel(s) à la deuxième exécution avec cache, 3 attendus", calls)
+	}
+}
@@ -0,0 +1,107 @@
+package societyai
+
+import (
+	"strings"
+	"text/template"
+)
+
+// ReportFinding est la contribution d'une perspective (ou d'une dimension) dans un rapport
+type ReportFinding struct {
+	AgentID     int    // Identifiant de l'agent
+	Perspective string // Perspective ou dimension traitée par l'agent
+	Model       string // Modèle utilisé par l'agent
+	Content     string // Réponse de l'agent
+}
+
+// ReportData contient les données disponibles pour un ReportTemplate
+type ReportData struct {
+	Title      string          // Titre du rapport, dérivé de la question
+	Question   string          // Prompt original
+	Mode       Mode            // Mode de fonctionnement utilisé
+	AgentCount int             // Nombre d'agents
+	RunID      string          // Identifiant de l'exécution
+	Findings   []ReportFinding // Contributions de chaque agent non écarté, dans l'ordre des agents
+	Conclusion string          // Synthèse ou réponse finale ("" en mode standard)
+}
+
+// ReportFuncs regroupe les fonctions utilisables dans un ReportTemplate personnalisé
+// (à déclarer avec template.New(...).Funcs(ReportFuncs)) : inc ajoute 1 à un entier
+var ReportFuncs = template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}
+
+// DefaultReportTemplate est le modèle de rapport markdown utilisé par RenderReport par défaut
+var DefaultReportTemplate = template.Must(template.New("report").Funcs(ReportFuncs).Parse(`# {{.Title}}
+
+## Question
+
+{{.Question}}
+
+## Méthodologie
+
+Mode {{.Mode}}, {{.AgentCount}} agent(s).
+
+## Analyses par perspective
+{{range .Findings}}
+### Agent {{inc .AgentID}} : {{.Perspective}}
+
+{{.Content}}
+{{end}}{{if .Conclusion}}
+## Conclusion
+
+{{.Conclusion}}
+{{end}}`))
+
+// NewReportData construit les données de rapport à partir du résultat détaillé d'une exécution
+func NewReportData(result *SocietyResult) ReportData {
+	data := ReportData{
+		Title:      reportTitle(result.Prompt),
+		Question:   result.Prompt,
+		Mode:       result.Mode,
+		AgentCount: len(result.Agents),
+		RunID:      result.RunID,
+		Conclusion: result.Synthesis,
+	}
+
+	for _, agent := range result.Agents {
+		if agent.Skipped || agent.Err != nil {
+			continue
+		}
+		data.Findings = append(data.Findings, ReportFinding{
+			AgentID:     agent.AgentID,
+			Perspective: agentPerspective(agent),
+			Model:       agent.ModelName,
+			Content:     agent.Output,
+		})
+	}
+
+	if result.Collaborative != nil {
+		data.Conclusion = result.Collaborative.String()
+	}
+	return data
+}
+
+// RenderReport met en forme le résultat avec le modèle fourni (DefaultReportTemplate si nil)
+func RenderReport(result *SocietyResult, tmpl *template.Template) (string, error) {
+	if tmpl == nil {
+		tmpl = DefaultReportTemplate
+	}
+
+	var report strings.Builder
+	if err := tmpl.Execute(&report, NewReportData(result)); err != nil {
+		return "", err
+	}
+	return report.String(), nil
+}
+
+// reportTitle dérive un titre court de la première ligne de la question
+func reportTitle(prompt string) string {
+	title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(prompt), "\n", 2)[0])
+	if short := truncateRunes(title, 80); short != title {
+		title = short + "…"
+	}
+	if title == "" {
+		return "Rapport"
+	}
+	return title
+}
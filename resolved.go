@@ -0,0 +1,67 @@
+package societyai
+
+// resolvedConfig retourne une copie de la configuration de la société dans laquelle les valeurs
+// par défaut appliquées pendant l'exécution remplacent les champs non renseignés (SocietyResult.ResolvedConfig).
+// Timeout reste nul lorsqu'il n'est pas défini : chaque phase applique alors son propre délai
+// (DefaultAgentTimeout pour les agents, DefaultExplorationTimeout pour l'exploration), sauf si le contexte
+// de l'appelant porte une échéance.
+func (s *SocietyGroup) resolvedConfig() *Config {
+	if s.config == nil {
+		return nil
+	}
+	resolved := *s.config
+
+	resolved.Language = resolveLanguage(s.config)
+	resolved.RunID = s.runID
+	resolved.Tags = s.runTags()
+	resolved.PhaseTemperatures = s.config.PhaseTemperatures.withDefaults()
+	resolved.ExplorationWaves = s.explorationWaves()
+	if resolved.Seed == 0 {
+		resolved.Seed = s.seed
+	}
+	resolved.Passes = s.config.passes()
+	if resolved.TreeSynthesisThreshold > 0 && resolved.TreeSynthesisBranching < 2 {
+		resolved.TreeSynthesisBranching = DefaultTreeSynthesisBranching
+	}
+	if resolved.Mode == "" {
+		resolved.Mode = ModeStandard
+	}
+	if resolved.RetryBackoff <= 0 {
+		resolved.RetryBackoff = DefaultRetryBackoff
+	}
+	if resolved.CorpusConcurrency <= 0 {
+		resolved.CorpusConcurrency = DefaultCorpusConcurrency
+	}
+	if resolved.BlockedPlaceholder == "" {
+		resolved.BlockedPlaceholder = DefaultBlockedPlaceholder
+	}
+	if resolved.HighAgreementThreshold == 0 {
+		resolved.HighAgreementThreshold = DefaultHighAgreementThreshold
+	}
+	if resolved.LowAgreementThreshold == 0 {
+		resolved.LowAgreementThreshold = DefaultLowAgreementThreshold
+	}
+	if resolved.ExtractiveSupportThreshold <= 0 {
+		resolved.ExtractiveSupportThreshold = DefaultExtractiveSupportThreshold
+	}
+	if resolved.EchoThreshold == 0 {
+		resolved.EchoThreshold = DefaultEchoThreshold
+	}
+	if resolved.Integrator == nil {
+		resolved.Integrator = PromptIntegrator{}
+	}
+	if resolved.Similarity == nil {
+		resolved.Similarity = JaccardSimilarity
+	}
+	if resolved.TokenCounter == nil {
+		resolved.TokenCounter = ApproximateTokenCounter
+	}
+	if resolved.Scheduler == nil {
+		// Ordonnanceur effectivement utilisé, borné par Config.MaxConcurrency le cas échéant
+		resolved.Scheduler = s.scheduler()
+	}
+	if resolved.SynthesisJudge == nil {
+		resolved.SynthesisJudge = resolved.SynthesisModel
+	}
+	return &resolved
+}
@@ -0,0 +1,23 @@
+package societyai_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/benoitpetit/societyai"
+	"github.com/benoitpetit/societyai/testmodel"
+)
+
+func TestResolvedConfigReportsBoundedScheduler(t *testing.T) {
+	config := societyai.NewConfig("Comment répartir la charge ?", 2)
+	config.MaxConcurrency = 1
+	result, err := societyai.RunSocietyDetailed(context.Background(), config, []societyai.AIModel{testmodel.New("model", nil)})
+	if err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+
+	scheduler, ok := result.ResolvedConfig.Scheduler.(societyai.BoundedScheduler)
+	if !ok || scheduler.Limit != 1 {
+		t.Errorf("ordonnanceur rapporté %#v, BoundedScheduler{Limit: 1} attendu", result.ResolvedConfig.Scheduler)
+	}
+}
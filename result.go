@@ -0,0 +1,262 @@
+package societyai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AgentResult contient le résultat individuel d'un agent
+type AgentResult struct {
+	AgentID      int     // Identifiant de l'agent
+	ModelName    string  // Nom du modèle utilisé par l'agent
+	Prompt       string  // Prompt envoyé à l'agent
+	Perspective  string  // Perspective attribuée à l'agent, ou sa sous-question (modes standard et synthèse)
+	Dimension    string  // Dimension explorée par l'agent (mode collaboratif uniquement)
+	Output       string  // Réponse produite par l'agent
+	FinishReason string  // Raison de fin rapportée par le modèle (si FinishReporter est implémenté)
+	Err          error   // Erreur éventuelle rencontrée par l'agent
+	Skipped      bool    // L'agent a été écarté (réponse bloquée, condition d'arrêt, reprise du prompt, échec avec AllowPartialResults)
+	Echo         bool    // La réponse reprend le prompt au lieu d'y répondre (Config.FilterPromptEchoes)
+	Weight       float64 // Poids de l'agent dans la synthèse (Config.AgentWeights)
+
+	StartedAt   time.Time // Début du traitement de l'agent
+	CompletedAt time.Time // Fin du traitement de l'agent
+}
+
+// SocietyResult contient le détail d'une exécution de la société
+type SocietyResult struct {
+	RunID                 string            // Identifiant de l'exécution (voir RunIDFromContext)
+	Tags                  map[string]string // Étiquettes de l'exécution (Config.Tags)
+	Duration              time.Duration     // Durée de l'exécution
+	Mode                  Mode              // Mode de fonctionnement utilisé
+	Prompt                string            // Prompt original
+	Agents                []AgentResult     // Résultats des agents, dans l'ordre des agents
+	Synthesis             string            // Synthèse produite par le modèle de synthèse (mode synthèse uniquement)
+	SynthesisCandidates   []string          // Synthèses candidates parmi lesquelles Synthesis a été retenue (Config.SynthesisCandidates)
+	Scratchpad            []string          // Notes déposées par les agents dans le Scratchpad (Config.Scratchpad)
+	DroppedAgents         []int             // Agents écartés de la synthèse pour respecter Config.MaxSynthesisInputTokens
+	UnsupportedStatements []string          // Phrases de la synthèse absentes des réponses des agents (Config.VerifyExtractiveSynthesis)
+	Response              string            // Réponse formatée, identique à celle retournée par RunSociety
+
+	Collaborative *CollaborativeResult // Détail des phases (mode collaboratif uniquement)
+	ModelCalls    int64                // Nombre d'appels effectivement envoyés aux modèles
+	Usage         Usage                // Tokens consommés par l'exécution (modèles implémentant UsageReporter)
+
+	// ResolvedConfig est la configuration effectivement utilisée, valeurs par défaut appliquées,
+	// pour comprendre le comportement d'une exécution ou la reproduire
+	ResolvedConfig *Config
+	// Agreement mesure l'accord entre les modèles des agents (Config.ComputeAgreementStats)
+	Agreement *AgreementStats
+}
+
+// ByPerspective regroupe les résultats des agents non écartés par perspective (ou par dimension
+// en mode collaboratif), dans l'ordre des agents au sein de chaque groupe
+func (r *SocietyResult) ByPerspective() map[string][]AgentResult {
+	groups := make(map[string][]AgentResult)
+	for _, agent := range r.Agents {
+		if agent.Skipped {
+			continue
+		}
+		key := agentPerspective(agent)
+		groups[key] = append(groups[key], agent)
+	}
+	return groups
+}
+
+// CollaborativeResult contient le détail d'une exécution en mode collaboratif.
+// Dimensions, Insights et FinishReasons sont indexés par agent : Insights[i] est toujours
+// l'analyse de la dimension Dimensions[i], quel que soit l'ordre dans lequel les agents ont terminé.
+type CollaborativeResult struct {
+	InitialAnalysis    string            // Analyse initiale du prompt
+	Dimensions         []string          // Dimension explorée par chaque agent
+	Insights           []string          // Analyse produite par chaque agent pour sa dimension
+	FinishReasons      []string          // Raison de fin rapportée pour chaque exploration de dimension
+	Routing            []RoutingDecision // Attribution des modèles aux dimensions (uniquement avec SmartRouting)
+	IntegratedAnalysis string            // Analyse intégrée issue de la phase d'intégration
+	Summary            string            // Résumé court de la réponse (uniquement si IncludeSummary est activé)
+	Response           string            // Réponse finale détaillée
+
+	// PhaseDurations donne la durée de chaque phase terminée (PhaseInitial, PhaseExplore,
+	// PhaseIntegrate, PhaseFinal), y compris la validation de son artefact par Config.PhaseGate
+	PhaseDurations map[string]time.Duration
+}
+
+// String retourne la réponse finale, précédée du résumé lorsqu'il a été demandé
+func (r *CollaborativeResult) String() string {
+	if r.Summary == "" {
+		return r.Response
+	}
+	return "Résumé:\n" + r.Summary + "\n\nRéponse détaillée:\n" + r.Response
+}
+
+// AgentOutput est la sortie d'un agent dans un Result
+type AgentOutput struct {
+	AgentID   int           // Identifiant de l'agent
+	ModelName string        // Nom du modèle utilisé par l'agent
+	Output    string        // Réponse produite par l'agent (vide en cas d'échec)
+	Err       error         // Erreur éventuelle rencontrée par l'agent
+	Duration  time.Duration // Durée du traitement de l'agent
+	Weight    float64       // Poids de l'agent dans la synthèse (Config.AgentWeights)
+}
+
+// Result est le résultat structuré d'une exécution, à exploiter pour composer sa propre présentation.
+// Les agents écartés sans erreur (réponse bloquée, condition d'arrêt, reprise du prompt) n'y figurent pas.
+type Result struct {
+	Prompt         string                   // Prompt original
+	AgentOutputs   []AgentOutput            // Sortie de chaque agent, dans l'ordre des agents
+	Synthesis      string                   // Synthèse du modèle de synthèse, réponse finale en mode collaboratif, ou consensus du débat
+	Rounds         [][]AgentOutput          // Sorties des agents à chaque tour du débat (SocietyDebate uniquement)
+	PhaseDurations map[string]time.Duration // Durée de chaque phase terminée (mode collaboratif uniquement)
+	TotalUsage     Usage                    // Tokens consommés par l'exécution (modèles implémentant UsageReporter)
+
+	formatted string // Réponse formatée par la société, retournée par String
+}
+
+// newResult construit le résultat structuré à partir du détail d'une exécution
+func newResult(detail *SocietyResult) *Result {
+	result := &Result{
+		Prompt:     detail.Prompt,
+		Synthesis:  detail.Synthesis,
+		formatted:  detail.Response,
+		TotalUsage: detail.Usage,
+	}
+	if detail.Collaborative != nil {
+		result.Synthesis = detail.Collaborative.String()
+		result.PhaseDurations = detail.Collaborative.PhaseDurations
+	}
+	result.AgentOutputs = agentOutputsOf(detail.Agents)
+	return result
+}
+
+// agentOutputsOf convertit les résultats des agents en sorties, sans les agents écartés sans erreur
+func agentOutputsOf(agents []AgentResult) []AgentOutput {
+	var outputs []AgentOutput
+	for _, agent := range agents {
+		if agent.Skipped && agent.Err == nil {
+			continue
+		}
+		outputs = append(outputs, AgentOutput{
+			AgentID:   agent.AgentID,
+			ModelName: agent.ModelName,
+			Output:    agent.Output,
+			Err:       agent.Err,
+			Duration:  agent.CompletedAt.Sub(agent.StartedAt),
+			Weight:    agent.Weight,
+		})
+	}
+	return outputs
+}
+
+// String retourne la réponse formatée, identique à celle des fonctions qui retournent une chaîne.
+// Pour un Result construit par l'appelant, la réponse est composée à partir des sorties des agents.
+func (r *Result) String() string {
+	if r.formatted != "" {
+		return r.formatted
+	}
+
+	text := "Synthèse des analyses des agents:\n\n"
+	for _, output := range r.AgentOutputs {
+		if output.Err != nil {
+			text += fmt.Sprintf("Agent %d: échec (%v)\n\n", output.AgentID+1, output.Err)
+			continue
+		}
+		text += fmt.Sprintf("Agent %d: %s\n\n", output.AgentID+1, output.Output)
+	}
+	if r.Synthesis != "" {
+		text += "\nConclusion consolidée (via modèle de synthèse):\n" + r.Synthesis
+	}
+	return text
+}
+
+// agentOutputJSON est la forme sérialisée d'un AgentOutput, l'erreur étant rendue par son message
+type agentOutputJSON struct {
+	agentOutputAlias
+	Err string `json:"Err,omitempty"`
+}
+
+// agentOutputAlias évite la récursion de MarshalJSON lors de la sérialisation
+type agentOutputAlias AgentOutput
+
+// MarshalJSON sérialise la sortie en rendant l'erreur éventuelle par son message
+func (o AgentOutput) MarshalJSON() ([]byte, error) {
+	aux := agentOutputJSON{agentOutputAlias: agentOutputAlias(o)}
+	if o.Err != nil {
+		aux.Err = o.Err.Error()
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON relit une sortie sérialisée par MarshalJSON
+func (o *AgentOutput) UnmarshalJSON(data []byte) error {
+	var aux agentOutputJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*o = AgentOutput(aux.agentOutputAlias)
+	o.Err = nil
+	if aux.Err != "" {
+		o.Err = errors.New(aux.Err)
+	}
+	return nil
+}
+
+// JSON sérialise le résultat (sorties des agents, synthèse, tours du débat, consommation, durées)
+// pour les traitements automatisés : les erreurs sont rendues par leur message et les durées
+// en nanosecondes. Les noms des champs JSON sont ceux des champs Go et constituent un contrat stable.
+func (r *Result) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Markdown présente le résultat en Markdown : la question, les réponses des agents (ou les tours du débat)
+// avec leur modèle et leur durée, la synthèse, puis la durée des phases et la consommation si elles sont connues
+func (r *Result) Markdown() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "## Question\n\n%s\n\n", r.Prompt)
+
+	if len(r.Rounds) > 0 {
+		for round, outputs := range r.Rounds {
+			fmt.Fprintf(&text, "## Tour %d\n\n", round+1)
+			writeAgentOutputsMarkdown(&text, outputs)
+		}
+	} else if len(r.AgentOutputs) > 0 {
+		text.WriteString("## Réponses des agents\n\n")
+		writeAgentOutputsMarkdown(&text, r.AgentOutputs)
+	}
+
+	if r.Synthesis != "" {
+		fmt.Fprintf(&text, "## Synthèse\n\n%s\n\n", r.Synthesis)
+	}
+
+	if len(r.PhaseDurations) > 0 {
+		text.WriteString("## Durée des phases\n\n")
+		for _, phase := range []string{PhaseInitial, PhaseExplore, PhaseIntegrate, PhaseFinal} {
+			if duration, ok := r.PhaseDurations[phase]; ok {
+				fmt.Fprintf(&text, "- %s : %s\n", phase, duration.Round(time.Millisecond))
+			}
+		}
+		text.WriteString("\n")
+	}
+
+	if r.TotalUsage != (Usage{}) {
+		fmt.Fprintf(&text, "## Consommation\n\n- Tokens envoyés : %d\n- Tokens générés : %d\n- Total : %d\n\n",
+			r.TotalUsage.PromptTokens, r.TotalUsage.CompletionTokens, r.TotalUsage.TotalTokens())
+	}
+
+	return strings.TrimRight(text.String(), "\n") + "\n"
+}
+
+// writeAgentOutputsMarkdown présente chaque sortie d'agent sous un titre indiquant son modèle et sa durée
+func writeAgentOutputsMarkdown(text *strings.Builder, outputs []AgentOutput) {
+	for _, output := range outputs {
+		fmt.Fprintf(text, "### Agent %d (%s, %s)\n\n", output.AgentID+1, output.ModelName, output.Duration.Round(time.Millisecond))
+		if output.Err != nil {
+			fmt.Fprintf(text, "_Échec : %v_\n\n", output.Err)
+			continue
+		}
+		fmt.Fprintf(text, "%s\n\n", output.Output)
+	}
+}
@@ -0,0 +1,134 @@
+package societyai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RetryableError est une interface optionnelle qu'une erreur de modèle peut implémenter pour indiquer
+// si une nouvelle tentative a une chance d'aboutir (Retryable() == false pour une requête invalide,
+// une clé d'API refusée...). Voir IsRetryable et NonRetryable.
+type RetryableError interface {
+	error
+	// Retryable indique si l'appel peut être relancé
+	Retryable() bool
+}
+
+// nonRetryableError marque une erreur comme définitive
+type nonRetryableError struct {
+	err error
+}
+
+// Error implémente l'interface error
+func (e *nonRetryableError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap expose l'erreur d'origine à errors.Is et errors.As
+func (e *nonRetryableError) Unwrap() error {
+	return e.err
+}
+
+// Retryable implémente l'interface RetryableError
+func (e *nonRetryableError) Retryable() bool {
+	return false
+}
+
+// NonRetryable marque err comme définitive : un modèle créé par NewRetryModel la retourne sans nouvelle tentative
+func NonRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &nonRetryableError{err: err}
+}
+
+// IsRetryable indique si une nouvelle tentative peut être faite après err : les erreurs dont
+// un RetryableError de la chaîne refuse la relance sont définitives, toutes les autres peuvent être relancées
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var retryable RetryableError
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	return true
+}
+
+// retryModel relance les appels en échec du modèle enveloppé
+type retryModel struct {
+	inner      AIModel
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+// NewRetryModel enveloppe inner pour relancer un appel en échec jusqu'à maxRetries fois,
+// avec un délai d'attente doublé à chaque nouvelle tentative (baseDelay, DefaultRetryBackoff par défaut).
+// Les erreurs définitives (voir IsRetryable) sont retournées sans nouvelle tentative, et l'attente
+// est interrompue dès l'annulation du contexte. Les paramètres de génération sont transmis
+// au modèle enveloppé lorsqu'il implémente ConfigurableAIModel.
+func NewRetryModel(inner AIModel, maxRetries int, baseDelay time.Duration) AIModel {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	if baseDelay <= 0 {
+		baseDelay = DefaultRetryBackoff
+	}
+	return &retryModel{inner: inner, maxRetries: maxRetries, baseDelay: baseDelay}
+}
+
+// Name implémente l'interface AIModel
+func (m *retryModel) Name() string {
+	return m.inner.Name()
+}
+
+// Process implémente l'interface AIModel
+func (m *retryModel) Process(ctx context.Context, prompt string) (string, error) {
+	return m.ProcessWithOptions(ctx, prompt, ProcessOptions{})
+}
+
+// ProcessWithOptions implémente l'interface ConfigurableAIModel
+func (m *retryModel) ProcessWithOptions(ctx context.Context, prompt string, opts ProcessOptions) (string, error) {
+	delay := m.baseDelay
+
+	var lastErr error
+	for attempt := 0; attempt <= m.maxRetries; attempt++ {
+		if attempt > 0 {
+			// Attendre avant la tentative suivante sans ignorer l'annulation du contexte
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return "", fmt.Errorf("abandon après %d tentative(s): %w", attempt, lastErr)
+			}
+			delay *= 2
+		}
+
+		result, err := processWithOptions(ctx, m.inner, prompt, opts)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		// Inutile de relancer une erreur définitive ou si le contexte de l'appelant est terminé
+		if !IsRetryable(err) || ctx.Err() != nil {
+			if attempt == 0 {
+				return "", err
+			}
+			return "", fmt.Errorf("échec après %d tentative(s): %w", attempt+1, err)
+		}
+	}
+
+	return "", fmt.Errorf("échec après %d tentative(s): %w", m.maxRetries+1, lastErr)
+}
+
+// LastUsage implémente l'interface UsageReporter en rapportant la consommation du modèle enveloppé
+func (m *retryModel) LastUsage() Usage {
+	if reporter, ok := m.inner.(UsageReporter); ok {
+		return reporter.LastUsage()
+	}
+	return Usage{}
+}
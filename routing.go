@@ -0,0 +1,79 @@
+package societyai
+
+import "strings"
+
+// CapabilityReporter est une interface optionnelle qu'un modèle peut implémenter pour déclarer
+// ses domaines de compétence (mots-clés comparés aux dimensions avec Config.SmartRouting)
+type CapabilityReporter interface {
+	// Capabilities retourne les mots-clés décrivant les points forts du modèle
+	Capabilities() []string
+}
+
+// RoutingDecision décrit l'attribution d'un modèle à une dimension en mode collaboratif
+type RoutingDecision struct {
+	Dimension string   // Dimension explorée
+	ModelName string   // Modèle retenu
+	Matched   []string // Capacités du modèle correspondant à la dimension
+	Fallback  bool     // Attribution par défaut (modulo), faute de modèle se démarquant
+}
+
+// routeModel choisit le modèle de l'agent i pour la dimension indiquée : le modèle dont les capacités
+// correspondent le mieux à la dimension, ou le modèle d'indice i modulo le nombre de modèles
+// lorsqu'aucun modèle ne se démarque
+func routeModel(models []AIModel, i int, dimension string) (AIModel, RoutingDecision) {
+	fallback := models[i%len(models)]
+	decision := RoutingDecision{Dimension: dimension, ModelName: fallback.Name(), Fallback: true}
+
+	best, bestScore, tie := -1, 0, false
+	var bestMatched []string
+	for m, model := range models {
+		matched := matchCapabilities(model, dimension)
+		switch {
+		case len(matched) > bestScore:
+			best, bestScore, bestMatched, tie = m, len(matched), matched, false
+		case len(matched) == bestScore && bestScore > 0:
+			tie = true
+		}
+	}
+	if best < 0 || tie {
+		return fallback, decision
+	}
+
+	return models[best], RoutingDecision{Dimension: dimension, ModelName: models[best].Name(), Matched: bestMatched}
+}
+
+// matchCapabilities retourne les capacités déclarées par le modèle dont chaque mot préfixe
+// un mot de la dimension (« pratique » correspond à « pratiques »)
+func matchCapabilities(model AIModel, dimension string) []string {
+	reporter, ok := model.(CapabilityReporter)
+	if !ok {
+		return nil
+	}
+
+	words := wordSet(dimension)
+	var matched []string
+	for _, capability := range reporter.Capabilities() {
+		capabilityWords := wordSet(capability)
+		if len(capabilityWords) == 0 {
+			continue
+		}
+		all := true
+		for capabilityWord := range capabilityWords {
+			found := false
+			for word := range words {
+				if strings.HasPrefix(word, capabilityWord) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				all = false
+				break
+			}
+		}
+		if all {
+			matched = append(matched, capability)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,59 @@
+package societyai
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// runIDKey est la clé de contexte sous laquelle l'identifiant d'exécution est stocké
+type runIDKey struct{}
+
+// NewRunID génère un identifiant d'exécution aléatoire
+func NewRunID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// Repli sur l'horloge si la source aléatoire est indisponible
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// WithRunID retourne un contexte portant l'identifiant d'exécution id
+func WithRunID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, id)
+}
+
+// RunIDFromContext retourne l'identifiant d'exécution porté par le contexte, ou "" s'il n'y en a pas.
+// Les modèles reçoivent ce contexte à chaque appel et peuvent ainsi corréler leurs journaux à l'exécution.
+func RunIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(runIDKey{}).(string)
+	return id
+}
+
+// startRun détermine l'identifiant de l'exécution (Config.RunID, sinon celui déjà porté par le contexte,
+// sinon un nouvel identifiant), l'enregistre dans la société et l'injecte dans le contexte
+// avec les étiquettes de l'exécution (voir TagsFromContext).
+// Elle prépare aussi les modèles pour la nouvelle exécution (voir ConversationTrimmer).
+func (s *SocietyGroup) startRun(ctx context.Context) context.Context {
+	id := s.config.RunID
+	if id == "" {
+		id = RunIDFromContext(ctx)
+	}
+	if id == "" {
+		id = NewRunID()
+	}
+	s.runID = id
+	s.startedAt = time.Now()
+
+	// Limiter l'historique des modèles réutilisés d'une exécution à l'autre
+	s.trimConversations()
+
+	ctx = WithRunID(ctx, id)
+	if tags := s.runTags(); tags != nil {
+		ctx = context.WithValue(ctx, tagsKey{}, tags)
+	}
+	return ctx
+}
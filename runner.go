@@ -0,0 +1,74 @@
+package societyai
+
+import (
+	"context"
+	"sync"
+)
+
+// Runner encadre les exécutions d'un service embarquant la société : il suit les exécutions en cours
+// et permet un arrêt propre via Shutdown.
+type Runner struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	closed   bool
+	inFlight sync.WaitGroup
+}
+
+// NewRunner crée un Runner dont les exécutions sont annulées à la fin de parent
+func NewRunner(parent context.Context) *Runner {
+	ctx, cancel := context.WithCancel(parent)
+	return &Runner{ctx: ctx, cancel: cancel}
+}
+
+// Run exécute la société dans le mode indiqué (voir RunDetailed). L'exécution est annulée
+// à la fin de ctx ou si Shutdown force l'arrêt. Retourne ErrRunnerClosed après Shutdown.
+func (r *Runner) Run(ctx context.Context, mode Mode, config *Config, models []AIModel) (*SocietyResult, error) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil, ErrRunnerClosed
+	}
+	r.inFlight.Add(1)
+	r.mu.Unlock()
+	defer r.inFlight.Done()
+
+	// Lier l'exécution au contexte de l'appelant et à celui du Runner
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-r.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return RunDetailed(ctx, mode, config, models)
+}
+
+// Shutdown cesse d'accepter de nouvelles exécutions et attend la fin de celles en cours.
+// Si ctx se termine avant, les exécutions restantes sont annulées et Shutdown retourne
+// l'erreur de ctx une fois qu'elles ont rendu la main.
+func (r *Runner) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		r.cancel()
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
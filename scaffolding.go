@@ -0,0 +1,100 @@
+package societyai
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ScaffoldingPhrases liste les fragments d'échafaudage des prompts internes et les méta-commentaires
+// qui ne doivent pas apparaître dans la réponse finale lorsque Config.CleanScaffolding est activé.
+// La liste peut être étendue globalement, ou par exécution via Config.ScaffoldingPhrases.
+var ScaffoldingPhrases = []string{
+	"Compréhension initiale de la demande",
+	"Compréhension initiale",
+	"Analyse intégrée",
+	"Analyse initiale",
+	"Dimension:",
+	"Synthèse des analyses des agents",
+	"Conclusion consolidée",
+	"En t'appuyant sur cette analyse intégrée",
+	"En te basant sur cette analyse initiale",
+	"Voici la réponse finale",
+	"Voici ma réponse",
+	"Réponse finale",
+	"Here is the final answer",
+	"Here is my answer",
+	"Final answer",
+}
+
+// cleanScaffolding retire de la réponse les lignes d'échafaudage connues. Une ligne qui commence
+// par une phrase connue (éventuellement en titre markdown ou en gras) est supprimée si elle ne contient
+// rien d'autre. Lorsque la phrase est suivie d'un séparateur (« : » ou « — ») puis d'un contenu, seul le préfixe
+// est retiré, en conservant le titre ou la puce markdown de la ligne ; dans tous les autres cas la ligne est
+// conservée telle quelle, pour ne pas tronquer une phrase du contenu (« Final answer is 42. »).
+func cleanScaffolding(text string, extra []string) string {
+	phrases := append(append([]string(nil), ScaffoldingPhrases...), extra...)
+
+	lines := strings.Split(text, "\n")
+	cleaned := make([]string, 0, len(lines))
+	for _, line := range lines {
+		lead := markdownLead.FindString(line)
+		content := strings.TrimSpace(strings.Trim(strings.TrimSpace(line[len(lead):]), "#*_ "))
+		removed := false
+		for _, phrase := range phrases {
+			if !hasPrefixFold(content, phrase) {
+				continue
+			}
+			rest, ok := scaffoldingRest(content[len(phrase):], phrase)
+			if !ok {
+				continue
+			}
+			if rest == "" {
+				removed = true
+			} else {
+				line = lead + rest
+			}
+			break
+		}
+		if !removed {
+			cleaned = append(cleaned, line)
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(cleaned, "\n"))
+}
+
+// hasPrefixFold indique si text commence par prefix, sans tenir compte de la casse
+func hasPrefixFold(text, prefix string) bool {
+	if len(text) < len(prefix) || !utf8.ValidString(text[:len(prefix)]) {
+		return false
+	}
+	return strings.EqualFold(text[:len(prefix)], prefix)
+}
+
+// markdownLead reconnaît le titre ou la puce markdown en tête d'une ligne
+var markdownLead = regexp.MustCompile(`^\s*(#{1,6}\s+|[-*+]\s+|\d+[.)]\s+)`)
+
+// scaffoldingRest retourne le contenu qui suit une phrase d'échafaudage (after), séparateur retiré.
+// ok est faux lorsque la phrase n'est suivie ni de la fin de ligne ni d'un séparateur (« : », « — »),
+// sauf si la phrase se termine elle-même par un séparateur (ex: "Dimension:").
+func scaffoldingRest(after, phrase string) (rest string, ok bool) {
+	rest = strings.TrimLeft(after, " *_")
+	switch {
+	case rest == "" || rest == ".":
+		return "", true
+	case strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "—"):
+		return strings.TrimLeft(rest, " :—*_"), true
+	case strings.HasSuffix(phrase, ":") || strings.HasSuffix(phrase, "—"):
+		return rest, true
+	}
+	return "", false
+}
+
+// cleanOutput applique le nettoyage de l'échafaudage si la configuration le demande
+func (s *SocietyGroup) cleanOutput(text string) string {
+	if s.config == nil || !s.config.CleanScaffolding {
+		return text
+	}
+	return cleanScaffolding(text, s.config.ScaffoldingPhrases)
+}
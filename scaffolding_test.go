@@ -0,0 +1,35 @@
+package societyai
+
+import "testing"
+
+func TestCleanScaffolding(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"ligne seule", "Réponse finale\nLe contenu.", "Le contenu."},
+		{"titre markdown", "## Final answer\nThe content.", "The content."},
+		{"préfixe en gras", "**Réponse finale** : il faut migrer.", "il faut migrer."},
+		{"préfixe suivi d'un point", "Final answer. Migrate now.", "Final answer. Migrate now."},
+		{"ligne terminée par un point", "Final answer.\nMigrate now.", "Migrate now."},
+		{"phrase suivie d'un contenu", "Final answer is 42.", "Final answer is 42."},
+		{"phrase suivie d'un complément", "Analyse initiale du marché : la demande croît.", "Analyse initiale du marché : la demande croît."},
+		{"complément en titre", "## Analyse initiale du marché", "## Analyse initiale du marché"},
+		{"tiret cadratin", "Réponse finale — il faut migrer.", "il faut migrer."},
+		{"titre conservé", "## Réponse finale : migrer", "## migrer"},
+		{"puce conservée", "- Final answer: 42", "- 42"},
+		{"phrase ponctuée", "Dimension:Coûts", "Coûts"},
+		{"mot prolongé en anglais", "Final answers are rarely simple.", "Final answers are rarely simple."},
+		{"mot prolongé en français", "Réponse finalement retenue : migrer.", "Réponse finalement retenue : migrer."},
+		{"phrase au milieu de la ligne", "Le texte de la Réponse finale.", "Le texte de la Réponse finale."},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := cleanScaffolding(tc.text, nil); got != tc.want {
+				t.Errorf("cleanScaffolding(%q) = %q, attendu %q", tc.text, got, tc.want)
+			}
+		})
+	}
+}
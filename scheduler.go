@@ -0,0 +1,93 @@
+package societyai
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Scheduler contrôle la manière dont les agents d'une société sont exécutés
+// (tous en parallèle, avec une concurrence bornée, séquentiellement, par priorité...).
+// Run doit appeler process au plus une fois par agent et ne retourner
+// qu'une fois tous les appels lancés terminés.
+type Scheduler interface {
+	// Run exécute process pour les agents fournis
+	Run(ctx context.Context, agents []*Agent, process func(ctx context.Context, a *Agent) error) error
+}
+
+// ParallelScheduler exécute tous les agents simultanément, chacun dans sa goroutine
+type ParallelScheduler struct{}
+
+// Run implémente l'interface Scheduler
+func (ParallelScheduler) Run(ctx context.Context, agents []*Agent, process func(ctx context.Context, a *Agent) error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(agents))
+
+	for i, agent := range agents {
+		wg.Add(1)
+		go func(i int, a *Agent) {
+			defer wg.Done()
+			errs[i] = process(ctx, a)
+		}(i, agent)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// BoundedScheduler exécute les agents en parallèle avec au plus Limit agents simultanés.
+// Une limite nulle ou négative équivaut à ParallelScheduler.
+type BoundedScheduler struct {
+	Limit int
+}
+
+// Run implémente l'interface Scheduler
+func (b BoundedScheduler) Run(ctx context.Context, agents []*Agent, process func(ctx context.Context, a *Agent) error) error {
+	if b.Limit <= 0 {
+		return ParallelScheduler{}.Run(ctx, agents, process)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(agents))
+	sem := make(chan struct{}, b.Limit)
+
+	for i, agent := range agents {
+		// Attendre une place libre sans ignorer l'annulation du contexte
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, a *Agent) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = process(ctx, a)
+		}(i, agent)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// SequentialScheduler exécute les agents l'un après l'autre, dans leur ordre
+type SequentialScheduler struct{}
+
+// Run implémente l'interface Scheduler
+func (SequentialScheduler) Run(ctx context.Context, agents []*Agent, process func(ctx context.Context, a *Agent) error) error {
+	errs := make([]error, len(agents))
+
+	for i, agent := range agents {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		errs[i] = process(ctx, agent)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,39 @@
+package societyai
+
+import (
+	"context"
+	"sync"
+)
+
+// Scratchpad est un tableau partagé entre les agents en mode standard : un modèle peut y lire
+// les notes déjà déposées et y ajouter les siennes via ScratchpadFromContext.
+// L'accès est protégé par un mutex ; les agents s'exécutant en parallèle, l'ordre des notes
+// dépend de l'ordre de complétion et n'est pas déterministe.
+type Scratchpad struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+// Append ajoute une note au tableau
+func (p *Scratchpad) Append(entry string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries = append(p.entries, entry)
+}
+
+// Entries retourne une copie des notes déposées jusqu'ici, dans leur ordre d'ajout
+func (p *Scratchpad) Entries() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.entries...)
+}
+
+// scratchpadKey est la clé de contexte sous laquelle le Scratchpad de la société est stocké
+type scratchpadKey struct{}
+
+// ScratchpadFromContext retourne le Scratchpad de la société transmis aux modèles,
+// ou nil si Config.Scratchpad n'est pas activé
+func ScratchpadFromContext(ctx context.Context) *Scratchpad {
+	pad, _ := ctx.Value(scratchpadKey{}).(*Scratchpad)
+	return pad
+}
@@ -0,0 +1,72 @@
+package societyai
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Similarity mesure la ressemblance entre deux textes, de 0 (aucun point commun) à 1 (identiques)
+type Similarity func(a, b string) float64
+
+// Seuils d'accord par défaut utilisés par AdaptiveSynthesisLength
+const (
+	// DefaultHighAgreementThreshold est la similarité moyenne au-delà de laquelle les agents sont considérés d'accord
+	DefaultHighAgreementThreshold = 0.6
+	// DefaultLowAgreementThreshold est la similarité moyenne en deçà de laquelle les agents sont considérés en désaccord
+	DefaultLowAgreementThreshold = 0.3
+)
+
+// JaccardSimilarity est la similarité par défaut : indice de Jaccard sur les ensembles de mots des deux textes
+func JaccardSimilarity(a, b string) float64 {
+	wordsA, wordsB := wordSet(a), wordSet(b)
+	if len(wordsA) == 0 && len(wordsB) == 0 {
+		return 1
+	}
+
+	intersection := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			intersection++
+		}
+	}
+	union := len(wordsA) + len(wordsB) - intersection
+
+	return float64(intersection) / float64(union)
+}
+
+// wordSet retourne l'ensemble des mots d'un texte, en minuscules
+func wordSet(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = true
+	}
+	return words
+}
+
+// meanPairwiseSimilarity retourne la similarité moyenne entre toutes les paires de textes
+// (1 lorsqu'il y a moins de deux textes)
+func meanPairwiseSimilarity(texts []string, similarity Similarity) float64 {
+	if len(texts) < 2 {
+		return 1
+	}
+
+	total, pairs := 0.0, 0
+	for i := 0; i < len(texts); i++ {
+		for j := i + 1; j < len(texts); j++ {
+			total += similarity(texts[i], texts[j])
+			pairs++
+		}
+	}
+
+	return total / float64(pairs)
+}
+
+// similarity retourne la mesure de similarité configurée, JaccardSimilarity par défaut
+func (s *SocietyGroup) similarity() Similarity {
+	if s.config != nil && s.config.Similarity != nil {
+		return s.config.Similarity
+	}
+	return JaccardSimilarity
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -13,6 +16,12 @@ import (
 // Cette fonction est un wrapper sur SocietyWithModels qui s'attend à ce que le développeur
 // fournisse directement les modèles d'IA à utiliser.
 func Society(prompt string, agentCount int, models []AIModel, multiModel bool) (string, error) {
+	return SocietyContext(context.Background(), prompt, agentCount, models, multiModel)
+}
+
+// SocietyContext est la variante de Society qui reçoit un contexte : son annulation ou son échéance
+// interrompt les appels aux modèles en cours.
+func SocietyContext(ctx context.Context, prompt string, agentCount int, models []AIModel, multiModel bool) (string, error) {
 	if agentCount <= 0 {
 		return "", ErrInvalidAgentCount
 	}
@@ -21,7 +30,7 @@ func Society(prompt string, agentCount int, models []AIModel, multiModel bool) (
 		return "", ErrNoModelsSpecified
 	}
 
-	return RunSociety(context.Background(), &Config{
+	return RunSociety(ctx, &Config{
 		Prompt:     prompt,
 		AgentCount: agentCount,
 		MultiModel: multiModel,
@@ -31,6 +40,12 @@ func Society(prompt string, agentCount int, models []AIModel, multiModel bool) (
 // SocietyWithSynthesis crée une société d'agents qui analysent le prompt et utilise
 // un modèle dédié pour synthétiser les résultats des agents.
 func SocietyWithSynthesis(prompt string, agentCount int, models []AIModel, multiModel bool, synthModel AIModel) (string, error) {
+	return SocietyWithSynthesisContext(context.Background(), prompt, agentCount, models, multiModel, synthModel)
+}
+
+// SocietyWithSynthesisContext est la variante de SocietyWithSynthesis qui reçoit un contexte :
+// son annulation ou son échéance interrompt les appels aux modèles en cours, synthèse comprise.
+func SocietyWithSynthesisContext(ctx context.Context, prompt string, agentCount int, models []AIModel, multiModel bool, synthModel AIModel) (string, error) {
 	if agentCount <= 0 {
 		return "", ErrInvalidAgentCount
 	}
@@ -43,7 +58,7 @@ func SocietyWithSynthesis(prompt string, agentCount int, models []AIModel, multi
 		return "", errors.New("le modèle de synthèse ne peut pas être nil")
 	}
 
-	return RunSocietyWithSynthesis(context.Background(), &Config{
+	return RunSocietyWithSynthesis(ctx, &Config{
 		Prompt:     prompt,
 		AgentCount: agentCount,
 		MultiModel: multiModel,
@@ -53,6 +68,12 @@ func SocietyWithSynthesis(prompt string, agentCount int, models []AIModel, multi
 // SocietyCollaborative crée une société d'agents qui travaillent ensemble de manière collaborative,
 // avec une analyse initiale commune et une exploration de dimensions complémentaires.
 func SocietyCollaborative(prompt string, agentCount int, models []AIModel, multiModel bool) (string, error) {
+	return SocietyCollaborativeContext(context.Background(), prompt, agentCount, models, multiModel)
+}
+
+// SocietyCollaborativeContext est la variante de SocietyCollaborative qui reçoit un contexte :
+// son annulation ou son échéance interrompt la phase en cours et ses appels aux modèles.
+func SocietyCollaborativeContext(ctx context.Context, prompt string, agentCount int, models []AIModel, multiModel bool) (string, error) {
 	if agentCount <= 0 {
 		return "", ErrInvalidAgentCount
 	}
@@ -61,7 +82,7 @@ func SocietyCollaborative(prompt string, agentCount int, models []AIModel, multi
 		return "", ErrNoModelsSpecified
 	}
 
-	return RunSocietyCollaborative(context.Background(), &Config{
+	return RunSocietyCollaborative(ctx, &Config{
 		Prompt:        prompt,
 		AgentCount:    agentCount,
 		MultiModel:    multiModel,
@@ -71,72 +92,316 @@ func SocietyCollaborative(prompt string, agentCount int, models []AIModel, multi
 
 // RunSociety exécute la société d'agents avec les configurations fournies et les modèles spécifiés
 func RunSociety(ctx context.Context, config *Config, models []AIModel) (string, error) {
+	result, err := RunSocietyDetailed(ctx, config, models)
+	if err != nil {
+		return "", err
+	}
+
+	return newResult(result).String(), nil
+}
+
+// RunSocietyResult exécute la société dans le mode Config.Mode (ModeStandard si vide)
+// et retourne le résultat structuré de l'exécution
+func RunSocietyResult(ctx context.Context, config *Config, models []AIModel) (*Result, error) {
+	mode := config.Mode
+	if mode == "" {
+		mode = ModeStandard
+	}
+	detail, err := RunDetailed(ctx, mode, config, models)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(detail), nil
+}
+
+// RunSocietyBatch exécute successivement la société pour chaque configuration avec les mêmes modèles.
+// Les réponses et les erreurs sont indexées par configuration : l'échec d'une configuration
+// n'interrompt pas les suivantes (erreur nil en cas de succès). Seule l'annulation de ctx
+// arrête le lot, les configurations restantes recevant alors l'erreur du contexte.
+func RunSocietyBatch(ctx context.Context, configs []*Config, models []AIModel) ([]string, []error) {
+	responses := make([]string, len(configs))
+	errs := make([]error, len(configs))
+
+	for i, config := range configs {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		responses[i], errs[i] = RunSociety(ctx, config, models)
+	}
+
+	return responses, errs
+}
+
+// RunSocietyDetailed exécute la société d'agents en mode standard et retourne
+// le résultat individuel de chaque agent en plus de la réponse formatée
+func RunSocietyDetailed(ctx context.Context, config *Config, models []AIModel) (result *SocietyResult, err error) {
+	// Ne lancer aucun agent si le contexte est déjà annulé
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Vérifier la configuration avant de lancer les agents
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateModels(models); err != nil {
+		return nil, err
+	}
+
 	// Création de la société
 	society := createSociety(config, models)
+	ctx = society.startRun(ctx)
+	ctx, cancel := society.withRunTimeout(ctx)
+	defer cancel()
+	defer func() { society.recordRun(ModeStandard, err) }()
+	society.startProgress(ModeStandard)
 
 	// Lancement des agents
-	err := society.run(ctx)
+	err = society.runPasses(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Attente et collecte des résultats
-	result := society.collectResults()
+	// Collecte des résultats, ou réponse coordonnée si un coordinateur est configuré
+	response, model := society.collectResults(), society.primaryAgent().Model
+	if coordinator := society.coordinatorModel(); coordinator != nil {
+		model = coordinator
+		response, err = society.coordinate(ctx, model)
+		if err != nil {
+			return nil, err
+		}
+	}
+	response, err = society.validateAnswer(ctx, model, response)
+	if err != nil {
+		return nil, err
+	}
 
+	society.finishProgress(PhaseAgents)
+	result = society.detailedResult(response)
+	result.Mode = ModeStandard
 	return result, nil
 }
 
+// CollectAgentOutputs exécute les agents comme en mode standard et retourne leurs résultats individuels,
+// dans l'ordre des agents, sans les combiner (ni présentation, ni synthèse, ni coordination).
+// En cas d'échec, les résultats obtenus sont retournés avec l'erreur.
+func CollectAgentOutputs(ctx context.Context, config *Config, models []AIModel) ([]AgentResult, error) {
+	// Ne lancer aucun agent si le contexte est déjà annulé
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Vérifier la configuration avant de lancer les agents
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateModels(models); err != nil {
+		return nil, err
+	}
+
+	// Création de la société
+	society := createSociety(config, models)
+	ctx = society.startRun(ctx)
+	ctx, cancel := society.withRunTimeout(ctx)
+	defer cancel()
+	society.startProgress(ModeStandard)
+
+	// Lancement des agents
+	err := society.runPasses(ctx)
+	society.finishProgress(PhaseAgents)
+	society.recordRun(ModeStandard, err)
+	return society.agentResults, err
+}
+
 // RunSocietyWithSynthesis exécute la société d'agents avec les configurations fournies
 // et utilise un modèle spécifique pour la synthèse finale
 func RunSocietyWithSynthesis(ctx context.Context, config *Config, models []AIModel, synthModel AIModel) (string, error) {
+	result, err := RunSocietyWithSynthesisDetailed(ctx, config, models, synthModel)
+	if err != nil {
+		return "", err
+	}
+
+	return newResult(result).String(), nil
+}
+
+// RunSocietyWithSynthesisDetailed exécute la société d'agents avec un modèle de synthèse
+// et retourne le résultat individuel de chaque agent ainsi que la synthèse
+func RunSocietyWithSynthesisDetailed(ctx context.Context, config *Config, models []AIModel, synthModel AIModel) (result *SocietyResult, err error) {
+	// Ne lancer aucun agent si le contexte est déjà annulé
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Vérifier la configuration avant de lancer les agents
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateModels(models); err != nil {
+		return nil, err
+	}
+	if synthModel == nil && config.Synthesizer == nil {
+		return nil, ErrNoSynthesisModel
+	}
+
 	// Création de la société
 	society := createSociety(config, models)
+	society.synthModel = synthModel
+	ctx = society.startRun(ctx)
+	ctx, cancel := society.withRunTimeout(ctx)
+	defer cancel()
+	defer func() { society.recordRun(ModeSynthesis, err) }()
+	society.startProgress(ModeSynthesis)
 
 	// Lancement des agents
-	err := society.run(ctx)
+	err = society.runPasses(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Attente et collecte des résultats avec le modèle de synthèse
-	result, err := society.collectResultsWithSynthesisModel(ctx, synthModel)
+	// Collecte des résultats avec le modèle de synthèse
+	response, err := society.collectResultsWithSynthesisModel(ctx, synthModel)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if synthModel == nil {
+		// Sans modèle de synthèse (Config.Synthesizer), la correction revient à l'agent principal
+		synthModel = society.primaryAgent().Model
+	}
+	response, err = society.validateAnswer(ctx, synthModel, response)
+	if err != nil {
+		return nil, err
 	}
 
+	society.finishProgress(PhaseSynthesis)
+	result = society.detailedResult(response)
+	result.Mode = ModeSynthesis
 	return result, nil
 }
 
 // RunSocietyCollaborative exécute la société d'agents en mode collaboratif
 // avec une réflexion profonde et partagée
 func RunSocietyCollaborative(ctx context.Context, config *Config, models []AIModel) (string, error) {
+	result, err := RunSocietyCollaborativeDetailed(ctx, config, models)
+	if err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
+
+// RunSocietyCollaborativeDetailed exécute la société d'agents en mode collaboratif
+// et retourne le détail de chaque phase en plus de la réponse finale
+func RunSocietyCollaborativeDetailed(ctx context.Context, config *Config, models []AIModel) (*CollaborativeResult, error) {
+	result, err := runCollaborative(ctx, config, models)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Collaborative, nil
+}
+
+// runCollaborative exécute les phases du mode collaboratif et retourne le résultat détaillé,
+// incluant le résultat d'exploration de chaque agent
+func runCollaborative(ctx context.Context, config *Config, models []AIModel) (result *SocietyResult, err error) {
+	// Ne lancer aucun agent si le contexte est déjà annulé
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Vérifier la configuration avant de lancer les agents
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateModels(models); err != nil {
+		return nil, err
+	}
+
 	// Création d'une société collaborative
 	society := createCollaborativeSociety(config, models)
+	ctx = society.startRun(ctx)
+	ctx, cancel := society.withRunTimeout(ctx)
+	defer cancel()
+	defer func() { society.recordRun(ModeCollaborative, err) }()
+	society.startProgress(ModeCollaborative)
 
 	// Étape 1: Analyse initiale du prompt
-	err := society.performInitialAnalysis(ctx)
+	society.startPhase(PhaseInitial)
+	err = society.performInitialAnalysis(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	initialAnalysis, err := society.passGate(PhaseInitial, society.Context.InitialAnalysis)
+	if err != nil {
+		return nil, err
+	}
+	society.shareAnalysis(initialAnalysis)
+	society.Context.InitialAnalysis = initialAnalysis
+	society.endPhase(PhaseInitial)
 
 	// Étape 2: Exploration des dimensions
+	society.startPhase(PhaseExplore)
 	err = society.exploreDimensions(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	for i, insight := range society.Context.SharedInsights {
+		society.Context.SharedInsights[i], err = society.passGate(PhaseExplore, insight)
+		if err != nil {
+			return nil, err
+		}
+	}
+	society.endPhase(PhaseExplore)
 
 	// Étape 3: Intégration des analyses
+	society.startPhase(PhaseIntegrate)
 	err = society.integrateAnalyses(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	society.advance(PhaseIntegrate)
+	integratedAnalysis, err := society.passGate(PhaseIntegrate, society.Context.IntegratedAnalysis)
+	if err != nil {
+		return nil, err
+	}
+	society.shareAnalysis(integratedAnalysis)
+	society.Context.IntegratedAnalysis = integratedAnalysis
+	society.endPhase(PhaseIntegrate)
 
 	// Étape 4: Génération de la réponse finale
-	result, err := society.generateFinalResponse(ctx)
+	society.startPhase(PhaseFinal)
+	response, err := society.generateFinalResponse(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	society.advance(PhaseFinal)
+	response, err = society.passGate(PhaseFinal, society.cleanOutput(response))
+	if err != nil {
+		return nil, err
+	}
+	response, err = society.validateAnswer(ctx, society.primaryAgent().Model, response)
+	if err != nil {
+		return nil, err
+	}
+	society.endPhase(PhaseFinal)
+
+	result = society.collaborativePartial(society.Context.SharedInsights)
+	result.Collaborative.IntegratedAnalysis = society.Context.IntegratedAnalysis
+	result.Collaborative.Response = response
+
+	// Étape optionnelle: résumé généré à partir de la réponse détaillée
+	if config.IncludeSummary {
+		result.Collaborative.Summary, err = society.generateSummary(ctx, response)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	society.finishProgress(PhaseFinal)
+	result.Response = result.Collaborative.String()
+	result.ModelCalls = society.modelCalls.Load()
+	result.Usage = society.usage.load()
+
 	return result, nil
 }
 
@@ -156,13 +421,18 @@ func createSociety(config *Config, models []AIModel) *SocietyGroup {
 		}
 
 		// Adapter légèrement le prompt pour chaque agent pour favoriser la diversité
-		agentPrompt := generatePromptForAgent(config.Prompt, i)
+		agentPrompt := generatePromptForAgent(config, i) + config.agentInstructions(i)
+		if config.DiversifyBeyondPerspectives && config.RepeatsPerPerspective == 0 && i >= len(config.perspectives()) {
+			// Au-delà du premier cycle, la perspective est déjà attribuée à un autre agent
+			agentPrompt += diversityInstruction(resolveLanguage(config), i+1, config.AgentCount)
+		}
 
 		agent := &Agent{
-			ID:      i,
-			Model:   model,
-			Prompt:  agentPrompt,
-			Results: results,
+			ID:          i,
+			Model:       model,
+			Prompt:      agentPrompt,
+			Results:     results,
+			Perspective: strings.TrimSuffix(PerspectiveForAgent(config, i), ": "),
 		}
 
 		agents = append(agents, agent)
@@ -173,6 +443,7 @@ func createSociety(config *Config, models []AIModel) *SocietyGroup {
 		Models:     models,
 		MultiModel: config.MultiModel,
 		Results:    results,
+		config:     config,
 	}
 }
 
@@ -182,17 +453,24 @@ func createCollaborativeSociety(config *Config, models []AIModel) *SocietyGroup
 	results := make(chan string, config.AgentCount)
 
 	// Définir les dimensions à explorer
-	dimensions := []string{
-		"Compréhension fondamentale et factuelle du sujet",
-		"Aspects pratiques et mise en œuvre concrète",
-		"Implications plus larges et considérations de contexte",
-		"Défis potentiels et approches pour les surmonter",
-		"Applications pratiques et exemples concrets",
+	dimensions := DefaultDimensions
+	if len(config.Dimensions) > 0 {
+		dimensions = config.Dimensions
+	}
+
+	// Mélanger les dimensions si demandé, sur une copie de la liste d'origine
+	var seed int64
+	if config.ShuffleDimensions {
+		seed = config.seed()
+		dimensions = append([]string(nil), dimensions...)
+		rand.New(rand.NewSource(seed)).Shuffle(len(dimensions), func(i, j int) {
+			dimensions[i], dimensions[j] = dimensions[j], dimensions[i]
+		})
 	}
 
-	// Limiter les dimensions au nombre d'agents
+	// Limiter les dimensions au nombre d'agents, sans modifier la liste d'origine
 	if len(dimensions) > config.AgentCount {
-		dimensions = dimensions[:config.AgentCount]
+		dimensions = dimensions[:config.AgentCount:config.AgentCount]
 	}
 
 	// Créer le contexte collaboratif
@@ -201,20 +479,26 @@ func createCollaborativeSociety(config *Config, models []AIModel) *SocietyGroup
 		SharedInsights: make([]string, 0),
 	}
 
+	var routing []RoutingDecision
 	for i := 0; i < config.AgentCount; i++ {
+		dimensionIndex := i % len(dimensions)
+
 		var model AIModel
-		if config.MultiModel && len(models) > 1 {
+		if config.MultiModel && len(models) > 1 && config.SmartRouting {
+			// Attribuer à la dimension le modèle dont les capacités lui correspondent le mieux
+			var decision RoutingDecision
+			model, decision = routeModel(models, i, dimensions[dimensionIndex])
+			routing = append(routing, decision)
+		} else if config.MultiModel && len(models) > 1 {
 			model = models[i%len(models)]
 		} else {
 			model = models[0]
 		}
 
-		dimensionIndex := i % len(dimensions)
-
 		agent := &Agent{
 			ID:                 i,
 			Model:              model,
-			Prompt:             config.Prompt, // Sera modifié lors des différentes phases
+			Prompt:             config.userPrompt(), // Sera modifié lors des différentes phases
 			Results:            results,
 			Phase:              0,
 			DimensionToExplore: dimensions[dimensionIndex],
@@ -229,7 +513,18 @@ func createCollaborativeSociety(config *Config, models []AIModel) *SocietyGroup
 		MultiModel: config.MultiModel,
 		Results:    results,
 		Context:    context,
+		config:     config,
+		routing:    routing,
+		seed:       seed,
+	}
+}
+
+// seed retourne Config.Seed, ou une graine tirée au hasard lorsqu'elle n'est pas définie
+func (c *Config) seed() int64 {
+	if c.Seed != 0 {
+		return c.Seed
 	}
+	return time.Now().UnixNano()
 }
 
 // performInitialAnalysis réalise l'analyse initiale du prompt
@@ -238,17 +533,25 @@ func (s *SocietyGroup) performInitialAnalysis(ctx context.Context) error {
 		return errors.New("aucun agent disponible pour l'analyse")
 	}
 
-	// Utiliser le premier agent pour l'analyse initiale
-	primaryAgent := s.Agents[0]
+	// Utiliser l'agent principal pour l'analyse initiale
+	primaryAgent := s.primaryAgent()
+	if primaryAgent.Model == nil {
+		return ErrNilModel
+	}
 
 	// Créer le prompt pour l'analyse initiale
 	analysisPrompt := "Analyse profondément cette demande pour en comprendre l'essence, les attentes implicites et explicites, " +
 		"et le niveau de détail approprié pour y répondre de manière optimale: " + primaryAgent.Prompt
 
-	// Effectuer l'analyse initiale
-	initialAnalysis, err := primaryAgent.Model.Process(ctx, analysisPrompt)
-	if err != nil {
-		return err
+	// Effectuer l'analyse initiale, sauf si elle a été fournie par la configuration
+	initialAnalysis := s.config.InitialAnalysis
+	if initialAnalysis == "" {
+		var err error
+		initialAnalysis, err = s.callModel(ctx, primaryAgent.Model, analysisPrompt, s.phaseOptions(PhaseInitial))
+		if err != nil {
+			return err
+		}
+		s.advance(PhaseInitial)
 	}
 
 	// Stocker l'analyse initiale dans le contexte partagé
@@ -262,61 +565,102 @@ func (s *SocietyGroup) performInitialAnalysis(ctx context.Context) error {
 	return nil
 }
 
+// DefaultExplorationTimeout est le délai accordé à l'exploration d'une dimension absente de Config.DimensionTimeouts ;
+// avec plusieurs vagues (Config.ExplorationWaves), chaque vague dispose de ce délai
+const DefaultExplorationTimeout = 60 * time.Second
+
+// DefaultAgentTimeout est le délai accordé aux agents des modes standard et synthèse lorsque
+// ni Config.Timeout ni l'échéance du contexte de l'appelant ne le fixent
+const DefaultAgentTimeout = 30 * time.Second
+
 // exploreDimensions fait explorer les différentes dimensions du sujet par les agents
 func (s *SocietyGroup) exploreDimensions(ctx context.Context) error {
-	var wg sync.WaitGroup
-	errs := make(chan error, len(s.Agents))
-
-	// Créer un contexte avec timeout pour éviter les blocages
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	// Créer un contexte avec timeout pour éviter les blocages : le délai de la phase couvre,
+	// pour chaque vague, l'exploration la plus longue autorisée
+	waves := splitWaves(s.Agents, s.explorationWaves())
+	var budget time.Duration
+	for _, wave := range waves {
+		longest := time.Duration(0)
+		for _, agent := range wave {
+			if timeout := s.explorationTimeout(agent.DimensionToExplore); timeout > longest {
+				longest = timeout
+			}
+		}
+		budget += longest
+	}
+	parent, ctx, cancel := s.phaseContext(ctx, budget)
 	defer cancel()
 
-	// Lancer l'exploration par chaque agent
-	for _, agent := range s.Agents {
-		wg.Add(1)
-		go func(a *Agent) {
-			defer wg.Done()
-
-			// Créer le prompt pour explorer la dimension spécifique
-			explorationPrompt := fmt.Sprintf(
-				"En te basant sur cette analyse initiale:\n\n%s\n\n"+
-					"Explore en profondeur cette dimension spécifique: %s\n\n"+
-					"Pour la question originale: %s\n\n"+
-					"Analyse cette dimension de manière détaillée et approfondie, en tenant compte des autres aspects "+
-					"mais en te concentrant particulièrement sur cette dimension. "+
-					"Pense étape par étape et développe une analyse nuancée et complète.",
-				a.SharedAnalysis,
-				a.DimensionToExplore,
-				a.Prompt,
-			)
-
-			// Explorer la dimension
-			result, err := a.Model.Process(ctx, explorationPrompt)
-			if err != nil {
-				errs <- err
-				return
+	// Lancer l'exploration par vagues successives (une seule vague par défaut) ;
+	// les agents d'une vague reçoivent les analyses des vagues précédentes
+	outcomes := make([]agentOutcome, 0, len(s.Agents))
+	var previous []DimensionInsight
+	var dimensionExpired atomic.Bool
+	for _, wave := range waves {
+		prior := formatPriorInsights(previous)
+		waveOutcomes := s.runAgents(ctx, s.explorationScheduler(), wave, func(ctx context.Context, a *Agent) (string, error) {
+			// Chaque dimension dispose de son propre délai lorsque Config.DimensionTimeouts est défini
+			if len(s.config.DimensionTimeouts) > 0 && !s.config.timeoutDisabled() {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, s.explorationTimeout(a.DimensionToExplore))
+				defer cancel()
+				defer func() {
+					if parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+						dimensionExpired.Store(true)
+					}
+				}()
 			}
 
-			// Envoyer le résultat
-			a.Results <- result
-		}(agent)
+			prompt, opts := explorationPrompt(a, prior), s.phaseOptions(PhaseExplore)
+			if words := s.config.agentMaxWords(a.ID); words > 0 {
+				prompt += maxWordsInstruction(resolveLanguage(s.config), words)
+				opts.MaxTokens = maxTokensForWords(words)
+			}
+			result, err := s.callModel(ctx, a.Model, prompt, opts)
+			a.FinishReason = finishReasonOf(a.Model)
+			s.advance(PhaseExplore)
+			s.notifyPhase(PhaseExplore, fmt.Sprintf("agent %d: %s", a.ID, a.DimensionToExplore))
+			return result, err
+		}, nil)
+
+		for i, outcome := range waveOutcomes {
+			if outcome.err == nil {
+				previous = append(previous, DimensionInsight{
+					Dimension: wave[i].DimensionToExplore,
+					Insight:   outcome.result,
+				})
+			}
+		}
+		outcomes = append(outcomes, waveOutcomes...)
 	}
 
-	// Attendre que tous les agents terminent ou qu'une erreur se produise
-	go func() {
-		wg.Wait()
-		close(errs)
-	}()
-
-	// Vérifier s'il y a des erreurs
-	for err := range errs {
-		return err
+	// Collecter les résultats d'exploration dans l'ordre des agents : l'analyse d'indice i
+	// correspond toujours à la dimension de l'agent i, quel que soit l'ordre de complétion
+	insights := make([]string, len(s.Agents))
+	s.applyBlockedPolicy(outcomes)
+	s.agentResults = s.recordResults(outcomes)
+	var errs []error
+	for i, outcome := range outcomes {
+		if outcome.err != nil {
+			errs = append(errs, &AgentError{
+				AgentID:   s.Agents[i].ID,
+				Dimension: s.Agents[i].DimensionToExplore,
+				Err:       outcome.err,
+			})
+			continue
+		}
+		insights[i] = outcome.result
 	}
 
-	// Collecter les résultats d'exploration
-	insights := make([]string, len(s.Agents))
-	for i := 0; i < len(s.Agents); i++ {
-		insights[i] = <-s.Results
+	if len(errs) > 0 {
+		// Distinguer l'expiration du délai interne des erreurs propres aux modèles
+		expired := dimensionExpired.Load() || errors.Is(ctx.Err(), context.DeadlineExceeded)
+		if err := societyTimeout(parent, expired, PhaseExplore, errs, func() *SocietyResult {
+			return s.collaborativePartial(insights)
+		}); err != nil {
+			return err
+		}
+		return errors.Join(errs...)
 	}
 
 	// Stocker les insights dans le contexte
@@ -325,40 +669,206 @@ func (s *SocietyGroup) exploreDimensions(ctx context.Context) error {
 	return nil
 }
 
+// startPhase marque le début d'une phase collaborative : son chronométrage commence et Config.OnPhase en est notifié
+func (s *SocietyGroup) startPhase(phase string) {
+	if s.phaseStarts == nil {
+		s.phaseStarts = make(map[string]time.Time)
+	}
+	s.phaseStarts[phase] = time.Now()
+	s.notifyPhase(phase, PhaseStarted)
+}
+
+// endPhase marque la fin d'une phase collaborative : sa durée est enregistrée et Config.OnPhase en est notifié
+func (s *SocietyGroup) endPhase(phase string) {
+	if startedAt, ok := s.phaseStarts[phase]; ok {
+		if s.phaseDurations == nil {
+			s.phaseDurations = make(map[string]time.Duration)
+		}
+		s.phaseDurations[phase] = time.Since(startedAt)
+	}
+	s.notifyPhase(phase, PhaseCompleted)
+}
+
+// notifyPhase transmet l'avancement d'une phase à Config.OnPhase, un appel à la fois
+func (s *SocietyGroup) notifyPhase(phase string, detail string) {
+	if s.config == nil || s.config.OnPhase == nil {
+		return
+	}
+
+	s.phaseMu.Lock()
+	defer s.phaseMu.Unlock()
+	s.config.OnPhase(phase, detail)
+}
+
+// passGate soumet l'artefact d'une phase à Config.PhaseGate, qui peut le modifier ou interrompre l'exécution
+func (s *SocietyGroup) passGate(phase string, artifact string) (string, error) {
+	if s.config == nil || s.config.PhaseGate == nil {
+		return artifact, nil
+	}
+
+	edited, proceed, err := s.config.PhaseGate(phase, artifact)
+	if err != nil {
+		return "", err
+	}
+	if !proceed {
+		return "", fmt.Errorf("%w (phase: %s)", ErrPipelineAborted, phase)
+	}
+	return edited, nil
+}
+
+// shareAnalysis partage une analyse avec tous les agents
+func (s *SocietyGroup) shareAnalysis(analysis string) {
+	for _, agent := range s.Agents {
+		agent.SharedAnalysis = analysis
+	}
+}
+
+// explorationPrompt crée le prompt demandant à l'agent d'explorer sa dimension spécifique,
+// en y ajoutant le cas échéant les analyses des vagues précédentes
+func explorationPrompt(a *Agent, priorInsights string) string {
+	prompt := fmt.Sprintf(
+		"En te basant sur cette analyse initiale:\n\n%s\n\n"+
+			"Explore en profondeur cette dimension spécifique: %s\n\n"+
+			"Pour la question originale: %s\n\n"+
+			"Analyse cette dimension de manière détaillée et approfondie, en tenant compte des autres aspects "+
+			"mais en te concentrant particulièrement sur cette dimension. "+
+			"Pense étape par étape et développe une analyse nuancée et complète.",
+		a.SharedAnalysis,
+		a.DimensionToExplore,
+		a.Prompt,
+	)
+
+	if priorInsights != "" {
+		prompt += "\n\nAnalyses déjà produites pour d'autres dimensions, à prendre en compte " +
+			"pour approfondir ta propre dimension sans les répéter:\n\n" + priorInsights
+	}
+
+	return prompt
+}
+
+// formatPriorInsights présente les analyses des vagues précédentes
+func formatPriorInsights(insights []DimensionInsight) string {
+	var text string
+	for _, insight := range insights {
+		text += fmt.Sprintf("Dimension: %s\n%s\n\n", insight.Dimension, insight.Insight)
+	}
+	return text
+}
+
+// explorationTimeout retourne le délai accordé à l'exploration d'une dimension
+func (s *SocietyGroup) explorationTimeout(dimension string) time.Duration {
+	if timeout, ok := s.config.DimensionTimeouts[dimension]; ok && timeout > 0 {
+		return timeout
+	}
+	return DefaultExplorationTimeout
+}
+
+// explorationWaves retourne le nombre de vagues d'exploration configuré (au moins 1)
+func (s *SocietyGroup) explorationWaves() int {
+	if s.config == nil || s.config.ExplorationWaves < 1 {
+		return 1
+	}
+	return s.config.ExplorationWaves
+}
+
+// splitWaves répartit les agents en vagues consécutives de tailles aussi égales que possible
+func splitWaves(agents []*Agent, waves int) [][]*Agent {
+	if waves > len(agents) {
+		waves = len(agents)
+	}
+	if waves < 1 {
+		waves = 1
+	}
+
+	result := make([][]*Agent, 0, waves)
+	start := 0
+	for w := 0; w < waves; w++ {
+		size := len(agents) / waves
+		if w < len(agents)%waves {
+			size++
+		}
+		result = append(result, agents[start:start+size])
+		start += size
+	}
+	return result
+}
+
+// collaborativePartial construit le résultat, éventuellement partiel, d'une exécution collaborative
+func (s *SocietyGroup) collaborativePartial(insights []string) *SocietyResult {
+	collaborative := &CollaborativeResult{
+		InitialAnalysis: s.Context.InitialAnalysis,
+		Dimensions:      make([]string, len(s.Agents)),
+		Insights:        insights,
+		FinishReasons:   make([]string, len(s.Agents)),
+		Routing:         s.routing,
+	}
+	if len(s.phaseDurations) > 0 {
+		collaborative.PhaseDurations = make(map[string]time.Duration, len(s.phaseDurations))
+		for phase, duration := range s.phaseDurations {
+			collaborative.PhaseDurations[phase] = duration
+		}
+	}
+	for i, agent := range s.Agents {
+		collaborative.Dimensions[i] = agent.DimensionToExplore
+		collaborative.FinishReasons[i] = agent.FinishReason
+	}
+	return &SocietyResult{
+		RunID:          s.runID,
+		Tags:           s.runTags(),
+		Duration:       time.Since(s.startedAt),
+		Mode:           ModeCollaborative,
+		Prompt:         s.config.Prompt,
+		Agents:         s.agentResults,
+		Collaborative:  collaborative,
+		ModelCalls:     s.modelCalls.Load(),
+		Usage:          s.usage.load(),
+		ResolvedConfig: s.resolvedConfig(),
+		Agreement:      s.agreementStats(),
+	}
+}
+
 // integrateAnalyses intègre les analyses des différentes dimensions
 func (s *SocietyGroup) integrateAnalyses(ctx context.Context) error {
 	if len(s.Agents) == 0 || len(s.Context.SharedInsights) == 0 {
 		return errors.New("aucune analyse à intégrer")
 	}
 
-	// Utiliser le premier agent pour l'intégration
-	primaryAgent := s.Agents[0]
-
-	// Créer le prompt pour l'intégration
-	integrationPrompt := "Intègre organiquement ces différentes analyses en une compréhension cohérente et unifiée:\n\n"
-
-	// Ajouter l'analyse initiale
-	integrationPrompt += "Compréhension initiale de la demande:\n" + s.Context.InitialAnalysis + "\n\n"
+	// Utiliser l'agent principal pour l'intégration
+	primaryAgent := s.primaryAgent()
+	if primaryAgent.Model == nil {
+		return ErrNilModel
+	}
 
-	// Ajouter les analyses des différentes dimensions
+	// Associer chaque analyse à la dimension explorée par l'agent qui l'a produite
+	insights := make([]DimensionInsight, 0, len(s.Context.SharedInsights))
 	for i, insight := range s.Context.SharedInsights {
-		integrationPrompt += fmt.Sprintf("Dimension: %s\n%s\n\n",
-			s.Agents[i].DimensionToExplore,
-			insight)
+		// Ignorer les dimensions dont la réponse bloquée a été écartée
+		if i < len(s.agentResults) && s.agentResults[i].Skipped {
+			continue
+		}
+		insights = append(insights, DimensionInsight{
+			Dimension: s.Agents[i].DimensionToExplore,
+			Insight:   insight,
+		})
+	}
+	if len(insights) == 0 {
+		return errors.New("aucune analyse à intégrer")
 	}
 
-	integrationPrompt += "Ta tâche est de synthétiser ces analyses en une compréhension intégrée qui combine " +
-		"organiquement toutes les dimensions, en évitant de simplement juxtaposer les informations. " +
-		"Identifie les connexions, les patterns et les idées transversales. " +
-		"Forme une analyse unifiée qui représente une réflexion collaborative approfondie."
+	var integrator Integrator = PromptIntegrator{}
+	if s.config != nil && s.config.Integrator != nil {
+		integrator = s.config.Integrator
+	}
 
 	// Effectuer l'intégration
-	integratedAnalysis, err := primaryAgent.Model.Process(ctx, integrationPrompt)
+	model := s.wrapModel(primaryAgent.Model, s.phaseOptions(PhaseIntegrate))
+	integratedAnalysis, err := integrator.Integrate(ctx, s.Context.InitialAnalysis, insights, model)
 	if err != nil {
 		return err
 	}
 
-	// Partager l'analyse intégrée avec tous les agents
+	// Stocker l'analyse intégrée et la partager avec tous les agents
+	s.Context.IntegratedAnalysis = integratedAnalysis
 	for _, agent := range s.Agents {
 		agent.SharedAnalysis = integratedAnalysis
 	}
@@ -366,14 +876,20 @@ func (s *SocietyGroup) integrateAnalyses(ctx context.Context) error {
 	return nil
 }
 
+// maxFinalInsightExcerpt limite la longueur de chaque analyse reprise dans le prompt de réponse finale
+const maxFinalInsightExcerpt = 1500
+
 // generateFinalResponse génère la réponse finale basée sur l'analyse intégrée
 func (s *SocietyGroup) generateFinalResponse(ctx context.Context) (string, error) {
 	if len(s.Agents) == 0 {
 		return "", errors.New("aucun agent disponible pour générer la réponse")
 	}
 
-	// Utiliser le premier agent pour la génération de la réponse finale
-	primaryAgent := s.Agents[0]
+	// Utiliser l'agent principal pour la génération de la réponse finale
+	primaryAgent := s.primaryAgent()
+	if primaryAgent.Model == nil {
+		return "", ErrNilModel
+	}
 
 	// Créer le prompt pour la réponse finale
 	responsePrompt := fmt.Sprintf(
@@ -387,8 +903,41 @@ func (s *SocietyGroup) generateFinalResponse(ctx context.Context) (string, error
 		primaryAgent.Prompt,
 	)
 
+	// Transmettre aussi les analyses des dimensions pour limiter la perte de détails à l'intégration
+	if s.config != nil && s.config.IncludeInsightsInFinal {
+		var insights []DimensionInsight
+		for i, insight := range s.Context.SharedInsights {
+			if insight == "" || i >= len(s.Agents) {
+				continue
+			}
+			excerpt := truncateRunes(insight, maxFinalInsightExcerpt)
+			if excerpt != insight {
+				excerpt += "…"
+			}
+			insights = append(insights, DimensionInsight{Dimension: s.Agents[i].DimensionToExplore, Insight: excerpt})
+		}
+		if len(insights) > 0 {
+			responsePrompt += "\n\nAnalyses détaillées de chaque dimension, pour ne perdre aucun détail utile:\n\n" +
+				strings.TrimSpace(formatPriorInsights(insights))
+		}
+	}
+
+	// Adapter la formulation au public visé
+	if s.config != nil && s.config.Audience != "" {
+		responsePrompt += "\n\n" + audienceInstruction(resolveLanguage(s.config), s.config.Audience)
+	}
+
+	// Imposer une structure markdown dont les sections suivent les dimensions explorées
+	if s.config != nil && s.config.StructuredOutput && len(s.Context.Dimensions) > 0 {
+		responsePrompt += "\n\nStructure la réponse en markdown, avec exactement une section de niveau 2 (##) " +
+			"par dimension, dans cet ordre et avec ces titres:\n"
+		for _, dimension := range s.Context.Dimensions {
+			responsePrompt += "## " + dimension + "\n"
+		}
+	}
+
 	// Générer la réponse finale
-	finalResponse, err := primaryAgent.Model.Process(ctx, responsePrompt)
+	finalResponse, err := s.callModel(ctx, primaryAgent.Model, responsePrompt, s.phaseOptions(PhaseFinal))
 	if err != nil {
 		return "", err
 	}
@@ -396,86 +945,630 @@ func (s *SocietyGroup) generateFinalResponse(ctx context.Context) (string, error
 	return finalResponse, nil
 }
 
+// generateSummary produit un résumé court de la réponse finale détaillée
+func (s *SocietyGroup) generateSummary(ctx context.Context, response string) (string, error) {
+	if len(s.Agents) == 0 {
+		return "", errors.New("aucun agent disponible pour générer le résumé")
+	}
+
+	primaryAgent := s.primaryAgent()
+	if primaryAgent.Model == nil {
+		return "", ErrNilModel
+	}
+
+	summaryPrompt := "Résume la réponse suivante en quelques phrases (TL;DR), en conservant uniquement " +
+		"les points essentiels et sans ajouter d'information nouvelle:\n\n" + response
+
+	return s.callModel(ctx, primaryAgent.Model, summaryPrompt, s.phaseOptions(PhaseFinal))
+}
+
 // generatePromptForAgent personnalise légèrement le prompt pour chaque agent
-func generatePromptForAgent(basePrompt string, agentID int) string {
-	// Exemples de perspectives différentes selon l'ID de l'agent
-	perspectives := []string{
-		"Analyse cette demande de manière factuelle et concise: ",
-		"Considère les implications et le contexte plus large de cette demande: ",
-		"Identifie les exigences spécifiques et le but de cette demande: ",
-		"Réfléchis aux approches les plus innovantes pour répondre à cette demande: ",
-		"Examine les aspects techniques et pratiques de cette demande: ",
+func generatePromptForAgent(config *Config, agentID int) string {
+	return PerspectiveForAgent(config, agentID) + config.userPrompt()
+}
+
+// PerspectiveForAgent retourne la perspective exacte que l'agent d'identifiant agentID recevra
+// en tête de son prompt en mode standard et synthèse, sans exécuter de modèle.
+// Les perspectives sont attribuées de manière cyclique et déterministe, ou par groupes de
+// Config.RepeatsPerPerspective agents consécutifs ; config peut être nil.
+func PerspectiveForAgent(config *Config, agentID int) string {
+	if agentID < 0 {
+		return ""
+	}
+	perspectives := config.perspectives()
+	if config != nil && config.RepeatsPerPerspective > 0 {
+		return perspectives[(agentID/config.RepeatsPerPerspective)%len(perspectives)]
+	}
+	return perspectives[agentID%len(perspectives)]
+}
+
+// DefaultDimensions liste les dimensions explorées par défaut en mode collaboratif, attribuées
+// aux agents selon leur ID, de manière cyclique
+var DefaultDimensions = []string{
+	"Compréhension fondamentale et factuelle du sujet",
+	"Aspects pratiques et mise en œuvre concrète",
+	"Implications plus larges et considérations de contexte",
+	"Défis potentiels et approches pour les surmonter",
+	"Applications pratiques et exemples concrets",
+}
+
+// PerspectiveCount est le nombre de perspectives intégrées attribuées aux agents (DefaultPerspectives)
+func PerspectiveCount() int {
+	return len(DefaultPerspectives)
+}
+
+// perspectives retourne les perspectives attribuées aux agents : Config.Perspectives si renseigné,
+// DefaultPerspectives sinon ; config peut être nil
+func (c *Config) perspectives() []string {
+	if c != nil && len(c.Perspectives) > 0 {
+		return c.Perspectives
+	}
+	return DefaultPerspectives
+}
+
+// DefaultPerspectives liste les perspectives attribuées par défaut aux agents selon leur ID, de manière
+// cyclique. Chaque perspective préfixe le prompt : elle se termine donc par un séparateur.
+var DefaultPerspectives = []string{
+	"Analyse cette demande de manière factuelle et concise: ",
+	"Considère les implications et le contexte plus large de cette demande: ",
+	"Identifie les exigences spécifiques et le but de cette demande: ",
+	"Réfléchis aux approches les plus innovantes pour répondre à cette demande: ",
+	"Examine les aspects techniques et pratiques de cette demande: ",
+}
+
+// agentOutcome représente l'issue du traitement d'un agent
+type agentOutcome struct {
+	result      string
+	err         error
+	skipped     bool      // L'agent a été écarté (réponse bloquée avec BlockedSkip)
+	startedAt   time.Time // Début du traitement de l'agent
+	completedAt time.Time // Fin du traitement de l'agent
+}
+
+// runAgents exécute fn pour chaque agent via l'ordonnanceur sched et attend que tous aient terminé.
+// Les issues sont retournées dans l'ordre des agents, indépendamment de l'ordre de complétion,
+// ce qui garantit qu'aucune goroutine ne reste bloquée et qu'aucun résultat n'est perdu.
+// Lorsque stop est fourni, il est appelé (de manière sérialisée) à chaque fin d'agent ; s'il retourne
+// true, les agents restants sont annulés et écartés des résultats.
+func (s *SocietyGroup) runAgents(ctx context.Context, sched Scheduler, agents []*Agent, fn func(ctx context.Context, a *Agent) (string, error), stop func(a *Agent, outcome agentOutcome) bool) []agentOutcome {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	outcomes := make([]agentOutcome, len(agents))
+	executed := make([]bool, len(agents))
+	indexes := make(map[*Agent]int, len(agents))
+	for i, agent := range agents {
+		indexes[agent] = i
+	}
+
+	// Détection des échecs identiques répétés pour interrompre la société au plus tôt
+	threshold := 0
+	if s.config != nil {
+		threshold = s.config.FailFastThreshold
 	}
+	var mu sync.Mutex
+	failures := make(map[string]int)
+	var failFastErr error
+	stopped := false
+
+	schedErr := sched.Run(ctx, agents, func(ctx context.Context, a *Agent) error {
+		i, ok := indexes[a]
+		if !ok {
+			return errors.New("agent inconnu transmis par l'ordonnanceur")
+		}
+		startedAt := time.Now()
+		result, err := fn(ctx, a)
+		outcome := agentOutcome{result: result, err: err, startedAt: startedAt, completedAt: time.Now()}
+		outcomes[i] = outcome
+		executed[i] = true
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil && threshold > 0 && !isContextError(err) {
+			failures[err.Error()]++
+			if failures[err.Error()] >= threshold && failFastErr == nil {
+				failFastErr = err
+				cancel(err)
+			}
+		}
+
+		// Évaluer la condition d'arrêt tant qu'elle n'a pas déjà été satisfaite
+		if stop != nil && !stopped && failFastErr == nil && stop(a, outcome) {
+			stopped = true
+			cancel(errStopConditionMet)
+		}
+		return err
+	})
 
-	perspective := perspectives[agentID%len(perspectives)]
-	return perspective + basePrompt
+	for i := range outcomes {
+		// Les agents interrompus par la condition d'arrêt sont écartés des résultats
+		if stopped && (!executed[i] || isContextError(outcomes[i].err)) {
+			outcomes[i] = agentOutcome{skipped: true}
+			continue
+		}
+
+		// Les agents interrompus par l'arrêt anticipé héritent de l'erreur répétée
+		if executed[i] {
+			if failFastErr != nil && isContextError(outcomes[i].err) {
+				outcomes[i].err = failFastErr
+			}
+			continue
+		}
+
+		// Les agents que l'ordonnanceur n'a pas exécutés sont comptés comme en échec
+		err := failFastErr
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err == nil {
+			err = schedErr
+		}
+		if err == nil {
+			err = ErrAgentNotScheduled
+		}
+		outcomes[i] = agentOutcome{err: err}
+	}
+
+	return outcomes
+}
+
+// errStopConditionMet est la cause d'annulation utilisée lorsque Config.StopCondition est satisfaite
+var errStopConditionMet = errors.New("condition d'arrêt satisfaite")
+
+// isContextError indique si une erreur provient de l'annulation ou de l'expiration d'un contexte
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
 
 // run lance tous les agents en parallèle
 func (s *SocietyGroup) run(ctx context.Context) error {
-	var wg sync.WaitGroup
-	errs := make(chan error, len(s.Agents))
-
 	// Créer un contexte avec timeout pour éviter les blocages
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	parent, ctx, cancel := s.phaseContext(ctx, DefaultAgentTimeout)
 	defer cancel()
 
-	// Lancer chaque agent dans une goroutine
-	for _, agent := range s.Agents {
-		wg.Add(1)
-		go func(a *Agent) {
-			defer wg.Done()
-			err := a.process(ctx)
-			if err != nil {
-				errs <- err
+	// Partager un tableau de notes entre les agents si demandé, le même pour toutes les passes
+	if s.config != nil && s.config.Scratchpad {
+		if s.Scratchpad == nil {
+			s.Scratchpad = &Scratchpad{}
+		}
+		ctx = context.WithValue(ctx, scratchpadKey{}, s.Scratchpad)
+	}
+
+	// Préparer les prompts des agents lors de la première passe uniquement
+	if !s.prepared {
+		s.prepared = true
+
+		// Attribuer une sous-question à chaque agent si la décomposition est demandée
+		if s.config != nil && s.config.DecomposePrompt {
+			s.decomposePrompt(ctx)
+		}
+
+		// Reformuler la question différemment pour chaque agent si demandé
+		if s.config != nil && s.config.RephraseQuestions && !s.config.DecomposePrompt {
+			s.rephraseQuestions(ctx)
+		}
+	}
+
+	// Regrouper les agents dont le modèle accepte les lots de prompts
+	// (sauf en diffusion vers des writers, où chaque agent est traité individuellement) ; les agents dont
+	// le modèle recevrait des paramètres de génération restent traités individuellement pour les conserver
+	var batches map[*Agent]*batchGroup
+	if s.writers == nil {
+		candidates := make([]*Agent, 0, len(s.Agents))
+		for _, agent := range s.Agents {
+			if !supportsOptions(agent.Model) || s.agentOptions(agent) == (ProcessOptions{}) {
+				candidates = append(candidates, agent)
 			}
-		}(agent)
+		}
+		batches = batchGroups(candidates, &s.modelCalls, &s.usage, s.compressPrompt)
 	}
 
-	// Attendre que tous les agents terminent ou qu'une erreur se produise
-	go func() {
-		wg.Wait()
-		close(errs)
-	}()
+	// Lancer chaque agent et attendre qu'ils aient tous terminé
+	outcomes := s.runAgents(ctx, s.scheduler(), s.Agents, func(ctx context.Context, a *Agent) (string, error) {
+		defer s.advance(PhaseAgents)
+		if batch, ok := batches[a]; ok {
+			result, err := batch.process(ctx, a)
+			a.FinishReason = finishReasonOf(a.Model)
+			return s.emitAgentEvent(a)(result, err)
+		}
+		return s.emitAgentEvent(a)(s.processAgent(ctx, a))
+	}, s.stopCondition())
+
+	// Traiter les réponses bloquées selon la politique configurée
+	s.applyBlockedPolicy(outcomes)
+
+	// Enregistrer le résultat de chaque agent dans l'ordre des agents
+	s.agentResults = s.recordResults(outcomes)
+
+	// Écarter les réponses qui ne font que reprendre le prompt
+	s.filterPromptEchoes(s.agentResults)
+
+	// Rassembler les erreurs de tous les agents, chacune attribuée à son agent : runAgents a attendu
+	// la fin de chaque agent, aucune erreur n'est donc perdue
+	var errs []error
+	for _, result := range s.agentResults {
+		if result.Err != nil {
+			errs = append(errs, &AgentError{AgentID: result.AgentID, Err: result.Err})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	// Avec AllowPartialResults, les agents en échec sont écartés et les réponses obtenues sont conservées,
+	// tant qu'au moins un agent a abouti
+	if s.config != nil && s.config.AllowPartialResults && s.toleratePartialFailure() {
+		return nil
+	}
 
-	// Vérifier s'il y a des erreurs
-	for err := range errs {
+	// Distinguer l'expiration du délai interne des erreurs propres aux modèles
+	if err := societyTimeout(parent, errors.Is(ctx.Err(), context.DeadlineExceeded), PhaseAgents, errs, func() *SocietyResult {
+		return s.detailedResult("")
+	}); err != nil {
 		return err
 	}
+	return errors.Join(errs...)
+}
 
-	return nil
+// withRunTimeout applique Config.Timeout à l'ensemble de l'exécution, via un unique contexte dérivé
+func (s *SocietyGroup) withRunTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	s.caller = ctx
+	if s.config.Timeout <= 0 || s.config.timeoutDisabled() {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, s.config.Timeout)
+}
+
+// phaseContext dérive le contexte d'une phase limitée par un délai interne. Lorsque Config.Timeout est défini,
+// il fait seul autorité et le délai interne est ignoré ; parent est alors le contexte de l'appelant,
+// afin que l'expiration de Config.Timeout soit rapportée comme un délai interne (TimeoutError).
+// Lorsque les délais sont désactivés (Config.DisableTimeout), ou que Config.Timeout n'est pas défini mais que
+// le contexte de l'appelant porte une échéance, la phase utilise directement ctx : l'échéance de l'appelant
+// fait alors autorité plutôt qu'un plafond arbitraire. Le délai interne ne s'applique qu'en l'absence des deux.
+func (s *SocietyGroup) phaseContext(ctx context.Context, internal time.Duration) (parent, phase context.Context, cancel context.CancelFunc) {
+	if s.config != nil && s.config.timeoutDisabled() {
+		return ctx, ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok && (s.config == nil || s.config.Timeout == 0) {
+		return ctx, ctx, func() {}
+	}
+	if s.config != nil && s.config.Timeout > 0 && s.caller != nil {
+		return s.caller, ctx, func() {}
+	}
+	phase, cancel = context.WithTimeout(ctx, internal)
+	return ctx, phase, cancel
+}
+
+// societyTimeout retourne une TimeoutError lorsque toutes les erreurs proviennent de l'expiration
+// d'un délai interne de la société (expired) et non du contexte de l'appelant, nil sinon
+func societyTimeout(parent context.Context, expired bool, phase string, errs []error, partial func() *SocietyResult) error {
+	for _, err := range errs {
+		if !isContextError(err) {
+			return nil
+		}
+	}
+	if parent.Err() != nil || !expired {
+		return nil
+	}
+	return &TimeoutError{Phase: phase, Partial: partial()}
+}
+
+// stopCondition adapte Config.StopCondition au suivi de fin des agents : les résultats partiels
+// lui sont transmis dans l'ordre d'arrivée, à chaque fin d'agent
+func (s *SocietyGroup) stopCondition() func(a *Agent, outcome agentOutcome) bool {
+	if s.config == nil || s.config.StopCondition == nil {
+		return nil
+	}
+
+	var partial []AgentResult
+	return func(a *Agent, outcome agentOutcome) bool {
+		partial = append(partial, AgentResult{
+			AgentID:      a.ID,
+			ModelName:    a.Model.Name(),
+			Prompt:       a.Prompt,
+			Output:       outcome.result,
+			FinishReason: a.FinishReason,
+			Err:          outcome.err,
+		})
+		snapshot := make([]AgentResult, len(partial))
+		copy(snapshot, partial)
+		return s.config.StopCondition(snapshot)
+	}
+}
+
+// applyBlockedPolicy applique Config.BlockedResponsePolicy aux agents dont la réponse a été bloquée
+func (s *SocietyGroup) applyBlockedPolicy(outcomes []agentOutcome) {
+	if s.config == nil || s.config.BlockedResponsePolicy == BlockedResponseFail {
+		return
+	}
+
+	for i := range outcomes {
+		if !isBlocked(outcomes[i].err) {
+			continue
+		}
+		switch s.config.BlockedResponsePolicy {
+		case BlockedResponseSkip:
+			outcomes[i].result, outcomes[i].err, outcomes[i].skipped = "", nil, true
+		case BlockedResponsePlaceholder:
+			placeholder := s.config.BlockedPlaceholder
+			if placeholder == "" {
+				placeholder = DefaultBlockedPlaceholder
+			}
+			outcomes[i].result, outcomes[i].err = placeholder, nil
+		}
+	}
 }
 
-// process traite le prompt avec le modèle de l'agent
-func (a *Agent) process(ctx context.Context) error {
-	result, err := a.Model.Process(ctx, a.Prompt)
+// recordResults convertit les issues des agents en résultats individuels, dans l'ordre des agents
+func (s *SocietyGroup) recordResults(outcomes []agentOutcome) []AgentResult {
+	results := make([]AgentResult, len(outcomes))
+	for i, outcome := range outcomes {
+		agent := s.Agents[i]
+		results[i] = AgentResult{
+			AgentID:      agent.ID,
+			ModelName:    agent.Model.Name(),
+			Prompt:       agent.Prompt,
+			Perspective:  agent.Perspective,
+			Dimension:    agent.DimensionToExplore,
+			Output:       outcome.result,
+			FinishReason: agent.FinishReason,
+			Err:          outcome.err,
+			Skipped:      outcome.skipped,
+			Weight:       s.config.agentWeight(agent.ID),
+			StartedAt:    outcome.startedAt,
+			CompletedAt:  outcome.completedAt,
+		}
+	}
+	return results
+}
+
+// agentTemperature répartit les températures des agents de manière régulière entre DefaultAgentTemperatureMin
+// et DefaultAgentTemperatureMax, dans l'ordre des agents (0, soit la température du modèle, avec
+// Config.DisableTemperatureSpread ; valeur médiane pour un agent unique)
+func (s *SocietyGroup) agentTemperature(agentID int) float64 {
+	if s.config.DisableTemperatureSpread {
+		return 0
+	}
+	if len(s.Agents) < 2 {
+		return (DefaultAgentTemperatureMin + DefaultAgentTemperatureMax) / 2
+	}
+	return DefaultAgentTemperatureMin +
+		(DefaultAgentTemperatureMax-DefaultAgentTemperatureMin)*float64(agentID)/float64(len(s.Agents)-1)
+}
+
+// agentOptions retourne les paramètres de génération de l'agent en modes standard et synthèse
+func (s *SocietyGroup) agentOptions(a *Agent) ProcessOptions {
+	var opts ProcessOptions
+	if s.config != nil {
+		opts.Temperature = s.agentTemperature(a.ID)
+		if words := s.config.agentMaxWords(a.ID); words > 0 {
+			opts.MaxTokens = maxTokensForWords(words)
+		}
+	}
+	return opts
+}
+
+// processAgent traite le prompt de l'agent avec son modèle
+func (s *SocietyGroup) processAgent(ctx context.Context, a *Agent) (string, error) {
+	opts := s.agentOptions(a)
+	var result string
+	var err error
+	if a.ID < len(s.writers) && s.writers[a.ID] != nil {
+		result, err = s.processAgentToWriter(ctx, a, s.writers[a.ID], opts)
+	} else {
+		result, err = s.callModel(ctx, a.Model, a.Prompt, opts)
+	}
+	a.FinishReason = finishReasonOf(a.Model)
+	return result, err
+}
+
+// callModel est le point de passage unique des appels de la société vers les modèles :
+// il compresse le prompt s'il est trop long puis l'envoie au modèle avec les paramètres fournis
+func (s *SocietyGroup) callModel(ctx context.Context, model AIModel, prompt string, opts ProcessOptions) (string, error) {
+	prompt, err := s.compressPrompt(ctx, model, prompt)
 	if err != nil {
-		return err
+		return "", err
 	}
+	return s.send(ctx, model, prompt, opts)
+}
 
-	// Envoyer le résultat dans le channel
-	a.Results <- result
+// send comptabilise l'appel et le transmet au modèle tel quel
+func (s *SocietyGroup) send(ctx context.Context, model AIModel, prompt string, opts ProcessOptions) (string, error) {
+	s.modelCalls.Add(1)
+	result, err := processWithOptions(ctx, model, prompt, opts)
+	s.usage.record(model)
+	return result, err
+}
 
-	return nil
+// processWithOptions appelle le modèle avec les paramètres de génération fournis lorsqu'il les supporte,
+// et se replie sur Process dans le cas contraire ou lorsqu'aucun paramètre n'est défini
+func processWithOptions(ctx context.Context, model AIModel, prompt string, opts ProcessOptions) (string, error) {
+	if opts == (ProcessOptions{}) {
+		return model.Process(ctx, prompt)
+	}
+	switch m := model.(type) {
+	case ConfigurableAIModel:
+		return m.ProcessWithOptions(ctx, prompt, opts)
+	case ParameterizedAIModel:
+		return m.ProcessWithParams(ctx, prompt, opts)
+	}
+	return model.Process(ctx, prompt)
 }
 
-// collectResults collecte les résultats de tous les agents
-func (s *SocietyGroup) collectResults() string {
-	var results []string
+// supportsOptions indique si le modèle accepte des paramètres de génération
+func supportsOptions(model AIModel) bool {
+	switch model.(type) {
+	case ConfigurableAIModel, ParameterizedAIModel:
+		return true
+	}
+	return false
+}
 
-	// Récupérer les résultats des agents
-	for i := 0; i < len(s.Agents); i++ {
-		result := <-s.Results
-		results = append(results, result)
+// societyModel fait passer les appels à Process par la société, avec des paramètres de génération fixes.
+// Il est transmis aux extensions (Integrator...) pour que leurs appels soient comptabilisés.
+type societyModel struct {
+	AIModel
+	society *SocietyGroup
+	opts    ProcessOptions
+	direct  bool // Envoyer les prompts sans compression
+}
+
+// Process implémente l'interface AIModel
+func (m societyModel) Process(ctx context.Context, prompt string) (string, error) {
+	if m.direct {
+		return m.society.send(ctx, m.AIModel, prompt, m.opts)
 	}
+	return m.society.callModel(ctx, m.AIModel, prompt, m.opts)
+}
 
+// wrapModel enveloppe un modèle pour que ses appels passent par la société avec les paramètres fournis
+func (s *SocietyGroup) wrapModel(model AIModel, opts ProcessOptions) AIModel {
+	return societyModel{AIModel: model, society: s, opts: opts}
+}
+
+// directModel enveloppe un modèle pour que ses appels soient comptabilisés sans être compressés
+func (s *SocietyGroup) directModel(model AIModel) AIModel {
+	return societyModel{AIModel: model, society: s, direct: true}
+}
+
+// phaseOptions retourne les paramètres de génération à appliquer pour une phase collaborative
+func (s *SocietyGroup) phaseOptions(phase string) ProcessOptions {
+	var temperatures PhaseTemperatures
+	if s.config != nil {
+		temperatures = s.config.PhaseTemperatures
+	}
+	temperatures = temperatures.withDefaults()
+
+	switch phase {
+	case PhaseInitial:
+		return ProcessOptions{Temperature: temperatures.Initial}
+	case PhaseExplore:
+		return ProcessOptions{Temperature: temperatures.Explore}
+	case PhaseIntegrate:
+		return ProcessOptions{Temperature: temperatures.Integrate}
+	case PhaseFinal:
+		return ProcessOptions{Temperature: temperatures.Final}
+	}
+	return ProcessOptions{}
+}
+
+// agentInstructions retourne les consignes ajoutées au prompt de l'agent en mode standard
+// (langue de réponse, longueur maximale)
+func (c *Config) agentInstructions(agentID int) string {
+	var instructions string
+	if c.Language != "" || c.AutoLanguage {
+		instructions += responseLanguageInstruction(resolveLanguage(c))
+	}
+	if words := c.agentMaxWords(agentID); words > 0 {
+		instructions += maxWordsInstruction(resolveLanguage(c), words)
+	}
+	return instructions
+}
+
+// agentMaxWords retourne la longueur maximale, en mots, de la réponse de l'agent (0 : illimitée)
+func (c *Config) agentMaxWords(agentID int) int {
+	if agentID < len(c.AgentMaxWordsByAgent) && c.AgentMaxWordsByAgent[agentID] > 0 {
+		return c.AgentMaxWordsByAgent[agentID]
+	}
+	return c.AgentMaxWords
+}
+
+// maxTokensForWords convertit une limite en mots en limite de tokens, avec une marge
+// pour que la consigne de concision, et non la coupure du modèle, détermine la longueur
+func maxTokensForWords(words int) int {
+	return words * 2
+}
+
+// finishReasonOf retourne la raison de fin rapportée par le modèle s'il implémente FinishReporter
+func finishReasonOf(model AIModel) string {
+	if reporter, ok := model.(FinishReporter); ok {
+		return reporter.LastFinishReason()
+	}
+	return ""
+}
+
+// scheduler retourne l'ordonnanceur configuré pour la société ; sans ordonnanceur explicite,
+// Config.MaxConcurrency borne le nombre d'agents simultanés
+func (s *SocietyGroup) scheduler() Scheduler {
+	if s.config != nil && s.config.Scheduler != nil {
+		return s.config.Scheduler
+	}
+	if s.config != nil && s.config.MaxConcurrency > 0 {
+		return BoundedScheduler{Limit: s.config.MaxConcurrency}
+	}
+	return ParallelScheduler{}
+}
+
+// explorationScheduler retourne l'ordonnanceur de la phase d'exploration collaborative, seule phase
+// parallèle de ce mode : Config.ExploreConcurrency la borne, sinon l'ordonnanceur configuré s'applique.
+// Les agents en attente d'une place sont écartés dès l'expiration du délai de la phase.
+func (s *SocietyGroup) explorationScheduler() Scheduler {
+	if s.config != nil && s.config.ExploreConcurrency > 0 {
+		return BoundedScheduler{Limit: s.config.ExploreConcurrency}
+	}
+	return s.scheduler()
+}
+
+// activeResults retourne les résultats des agents qui n'ont pas été écartés, dans l'ordre des agents
+func (s *SocietyGroup) activeResults() []AgentResult {
+	results := make([]AgentResult, 0, len(s.agentResults))
+	for _, result := range s.agentResults {
+		if !result.Skipped {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// agentOutputs retourne les sorties des agents qui n'ont pas été écartés, dans l'ordre des agents
+func (s *SocietyGroup) agentOutputs() []string {
+	active := s.activeResults()
+	results := make([]string, len(active))
+	for i, result := range active {
+		results[i] = result.Output
+	}
+	return results
+}
+
+// detailedResult construit le résultat détaillé d'une exécution en mode standard ou synthèse
+func (s *SocietyGroup) detailedResult(response string) *SocietyResult {
+	return &SocietyResult{
+		RunID:                 s.runID,
+		Tags:                  s.runTags(),
+		Duration:              time.Since(s.startedAt),
+		Prompt:                s.config.Prompt,
+		Agents:                s.agentResults,
+		Synthesis:             s.synthesis,
+		SynthesisCandidates:   s.synthesisCandidates,
+		Scratchpad:            s.scratchpadEntries(),
+		DroppedAgents:         s.droppedAgents,
+		UnsupportedStatements: s.unsupportedStatements,
+		Response:              response,
+		ModelCalls:            s.modelCalls.Load(),
+		Usage:                 s.usage.load(),
+		ResolvedConfig:        s.resolvedConfig(),
+		Agreement:             s.agreementStats(),
+	}
+}
+
+// scratchpadEntries retourne les notes du Scratchpad, s'il a été activé
+func (s *SocietyGroup) scratchpadEntries() []string {
+	if s.Scratchpad == nil {
+		return nil
+	}
+	return s.Scratchpad.Entries()
+}
+
+// collectResults collecte les résultats de tous les agents
+func (s *SocietyGroup) collectResults() string {
 	// Combiner les résultats
-	// Dans une implémentation plus avancée, on pourrait faire une analyse de consensus
-	// ou utiliser un agent "coordinateur" pour synthétiser les résultats
+	// (pour une réponse coordonnée par un agent "coordinateur", voir Config.CoordinatorModel et Config.UseCoordinator)
 	finalResult := "Synthèse des analyses des agents:\n\n"
-	for i, result := range results {
-		finalResult += fmt.Sprintf("Agent %d: %s\n\n", i+1, result)
+	for _, result := range s.activeResults() {
+		finalResult += fmt.Sprintf("Agent %d: %s\n\n", result.AgentID+1, result.Output)
 	}
+	finalResult += s.failureNotes()
 
 	// Suppression de la conclusion consolidée dans le mode standard
 	// car elle porte à confusion et suggère une synthèse qui n'existe pas dans ce mode
@@ -485,22 +1578,30 @@ func (s *SocietyGroup) collectResults() string {
 
 // collectResultsWithSynthesisModel collecte les résultats et utilise un modèle dédié pour la synthèse
 func (s *SocietyGroup) collectResultsWithSynthesisModel(ctx context.Context, synthesisModel AIModel) (string, error) {
-	var results []string
-
 	// Récupérer les résultats des agents
-	for i := 0; i < len(s.Agents); i++ {
-		result := <-s.Results
-		results = append(results, result)
-	}
+	results := s.agentOutputs()
 
-	// Présentation des résultats individuels
+	// Présentation des résultats individuels, numérotés comme dans le prompt de synthèse
 	finalResult := "Synthèse des analyses des agents:\n\n"
-	for i, result := range results {
-		finalResult += fmt.Sprintf("Agent %d: %s\n\n", i+1, result)
+	for _, result := range s.activeResults() {
+		finalResult += fmt.Sprintf("Agent %d: %s\n\n", result.AgentID+1, result.Output)
 	}
+	finalResult += s.failureNotes()
 
 	// Utiliser le modèle de synthèse pour créer une conclusion consolidée
-	synthesis, err := SynthesizeWithModel(ctx, results, synthesisModel)
+	inputs := s.fitSynthesisBudget(s.synthesisInputs())
+	var synthesis string
+	var err error
+	if s.config.Synthesizer != nil {
+		// Synthèse confiée au synthétiseur de la configuration, à partir des réponses retenues
+		texts := make([]string, len(inputs))
+		for i, input := range inputs {
+			texts[i] = input.Output
+		}
+		synthesis, err = s.config.Synthesizer.Synthesize(ctx, s.config.Prompt, texts)
+	} else {
+		synthesis, err = s.synthesize(ctx, synthesisModel, inputs)
+	}
 	if err != nil {
 		// En cas d'erreur, utiliser la méthode simple
 		finalResult += "\nConclusion consolidée (méthode simple - erreur du modèle de synthèse):\n" +
@@ -509,43 +1610,70 @@ func (s *SocietyGroup) collectResultsWithSynthesisModel(ctx context.Context, syn
 		return finalResult, nil
 	}
 
+	synthesis = s.cleanOutput(synthesis)
+	if strings.TrimSpace(synthesis) == "" {
+		// Une synthèse vide serait trompeuse : utiliser la méthode simple, comme en cas d'erreur
+		finalResult += "\nConclusion consolidée (méthode simple - synthèse vide):\n" +
+			synthesizeResults(results) +
+			"\n\nLe modèle de synthèse n'a retourné aucun contenu."
+		return finalResult, nil
+	}
+	s.synthesis = synthesis
+	if s.config.ExtractiveSynthesis && s.config.VerifyExtractiveSynthesis {
+		outputs := make([]string, len(inputs))
+		for i, input := range inputs {
+			outputs[i] = input.Output
+		}
+		s.unsupportedStatements = unsupportedStatements(synthesis, outputs, s.config.ExtractiveSupportThreshold)
+	}
 	finalResult += "\nConclusion consolidée (via modèle de synthèse):\n" + synthesis
 
 	return finalResult, nil
 }
 
-// synthesizeResults combine les résultats des agents en une réponse cohérente
-func synthesizeResults(results []string) string {
-	// Cette fonction pourrait être améliorée pour faire une véritable
-	// analyse et synthèse des différentes réponses
-
-	// Pour l'exemple actuel, nous faisons une simple concaténation
-	var synthesis string
-	for i, result := range results {
-		synthesis += fmt.Sprintf("\nAgent %d:\n%s\n", i+1, result)
+// primaryAgent retourne l'agent chargé des phases à agent unique (Config.PrimaryAgentID, le premier par défaut)
+func (s *SocietyGroup) primaryAgent() *Agent {
+	if s.config != nil && s.config.PrimaryAgentID > 0 && s.config.PrimaryAgentID < len(s.Agents) {
+		return s.Agents[s.config.PrimaryAgentID]
 	}
-
-	return "Synthèse des résultats:\n" + synthesis
+	if len(s.Agents) == 0 {
+		// Société vide : un agent sans modèle, que les appelants signalent par ErrNilModel
+		return &Agent{}
+	}
+	return s.Agents[0]
 }
 
-// SynthesizeWithModel combine les résultats des agents en utilisant un modèle spécifique
-func SynthesizeWithModel(ctx context.Context, results []string, model AIModel) (string, error) {
-	// Créer un prompt qui demande au modèle de synthétiser les perspectives
-	// des différents agents
-	prompt := "Analyse et synthétise les perspectives suivantes des agents en une réponse cohérente et approfondie:\n\n"
-
-	// Ajouter chaque résultat d'agent au prompt
-	for i, result := range results {
-		prompt += fmt.Sprintf("=== AGENT %d ===\n%s\n\n", i+1, result)
+// toleratePartialFailure écarte les agents en échec lorsqu'au moins un agent a abouti (Config.AllowPartialResults).
+// Retourne false, sans rien modifier, si aucun agent n'a abouti.
+func (s *SocietyGroup) toleratePartialFailure() bool {
+	succeeded := false
+	for _, result := range s.agentResults {
+		if result.Err == nil && !result.Skipped {
+			succeeded = true
+			break
+		}
 	}
+	if !succeeded {
+		return false
+	}
+	for i := range s.agentResults {
+		if s.agentResults[i].Err != nil {
+			s.agentResults[i].Skipped = true
+		}
+	}
+	return true
+}
 
-	prompt += "Ta tâche est de produire une synthèse complète qui:\n" +
-		"1. Identifie les points d'accord et de désaccord entre les agents\n" +
-		"2. Combine les perspectives uniques en une vision cohérente\n" +
-		"3. Présente une conclusion qui intègre les meilleures idées de chaque agent\n" +
-		"4. Offre une réponse finale plus complète que chacune des perspectives individuelles\n\n" +
-		"Synthèse:"
-
-	// Utiliser le modèle fourni pour générer la synthèse
-	return model.Process(ctx, prompt)
+// failureNotes signale dans la réponse les agents écartés après un échec, avec la cause de chacun
+func (s *SocietyGroup) failureNotes() string {
+	notes := ""
+	for _, result := range s.agentResults {
+		if result.Err != nil && result.Skipped {
+			notes += fmt.Sprintf("Agent %d: échec (%v)\n", result.AgentID+1, result.Err)
+		}
+	}
+	if notes == "" {
+		return ""
+	}
+	return "Agents en échec:\n" + notes + "\n"
 }
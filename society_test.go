@@ -0,0 +1,251 @@
+package societyai_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/benoitpetit/societyai"
+	"github.com/benoitpetit/societyai/testmodel"
+)
+
+func TestRunsRejectedBeforeModelCalls(t *testing.T) {
+	modes := []struct {
+		name string
+		run  func(ctx context.Context, config *societyai.Config, models []societyai.AIModel) error
+	}{
+		{"standard", func(ctx context.Context, config *societyai.Config, models []societyai.AIModel) error {
+			_, err := societyai.RunDetailed(ctx, societyai.ModeStandard, config, models)
+			return err
+		}},
+		{"synthesis", func(ctx context.Context, config *societyai.Config, models []societyai.AIModel) error {
+			_, err := societyai.RunSocietyWithSynthesisDetailed(ctx, config, models, models[0])
+			return err
+		}},
+		{"collaborative", func(ctx context.Context, config *societyai.Config, models []societyai.AIModel) error {
+			_, err := societyai.RunDetailed(ctx, societyai.ModeCollaborative, config, models)
+			return err
+		}},
+		{"debate", func(ctx context.Context, config *societyai.Config, models []societyai.AIModel) error {
+			_, err := societyai.SocietyDebate(ctx, config.Prompt, config.AgentCount, models, 2)
+			return err
+		}},
+		{"consensus", func(ctx context.Context, config *societyai.Config, models []societyai.AIModel) error {
+			_, err := societyai.RunSocietyConsensus(ctx, config, models)
+			return err
+		}},
+	}
+
+	cases := []struct {
+		name       string
+		cancelled  bool
+		agentCount int
+		want       error
+	}{
+		{"contexte annulé", true, 3, context.Canceled},
+		{"sans agent", false, 0, societyai.ErrInvalidAgentCount},
+	}
+
+	for _, mode := range modes {
+		for _, tc := range cases {
+			t.Run(mode.name+"/"+tc.name, func(t *testing.T) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				if tc.cancelled {
+					cancel()
+				}
+
+				model := testmodel.New("model", nil)
+				config := societyai.NewConfig("Quel langage choisir pour un service réseau ?", tc.agentCount)
+				err := mode.run(ctx, config, []societyai.AIModel{model})
+				if !errors.Is(err, tc.want) {
+					t.Fatalf("erreur attendue %v, obtenu %v", tc.want, err)
+				}
+				if calls := model.Calls(); calls != 0 {
+					t.Errorf("%d appel(s) au modèle, aucun attendu", calls)
+				}
+			})
+		}
+	}
+}
+
+func TestEstimateModelCallsMatchesModelCalls(t *testing.T) {
+	cases := []struct {
+		name      string
+		mode      societyai.Mode
+		configure func(config *societyai.Config, model societyai.AIModel)
+	}{
+		{"standard", societyai.ModeStandard, nil},
+		{"standard/passes", societyai.ModeStandard, func(config *societyai.Config, _ societyai.AIModel) {
+			config.Passes = 3
+		}},
+		{"standard/coordinator", societyai.ModeStandard, func(config *societyai.Config, _ societyai.AIModel) {
+			config.UseCoordinator = true
+		}},
+		{"standard/rephrase", societyai.ModeStandard, func(config *societyai.Config, _ societyai.AIModel) {
+			config.RephraseQuestions = true
+		}},
+		{"synthesis", societyai.ModeSynthesis, func(config *societyai.Config, model societyai.AIModel) {
+			config.SynthesisModel = model
+		}},
+		{"synthesis/passes", societyai.ModeSynthesis, func(config *societyai.Config, model societyai.AIModel) {
+			config.SynthesisModel = model
+			config.Passes = 2
+		}},
+		{"synthesis/tree", societyai.ModeSynthesis, func(config *societyai.Config, model societyai.AIModel) {
+			config.SynthesisModel = model
+			config.AgentCount = 7
+			config.TreeSynthesisThreshold = 3
+			config.TreeSynthesisBranching = 2
+		}},
+		{"synthesis/rephrase", societyai.ModeSynthesis, func(config *societyai.Config, model societyai.AIModel) {
+			config.SynthesisModel = model
+			config.RephraseQuestions = true
+		}},
+		{"collaborative", societyai.ModeCollaborative, nil},
+		{"collaborative/summary", societyai.ModeCollaborative, func(config *societyai.Config, _ societyai.AIModel) {
+			config.IncludeSummary = true
+		}},
+		{"collaborative/initial-analysis", societyai.ModeCollaborative, func(config *societyai.Config, _ societyai.AIModel) {
+			config.InitialAnalysis = "Analyse fournie"
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			model := testmodel.New("model", func(prompt string) (string, error) {
+				return "Une réponse argumentée.", nil
+			})
+			config := societyai.NewConfig("Faut-il migrer vers des microservices ?", 3)
+			if tc.configure != nil {
+				tc.configure(config, model)
+			}
+
+			// Deux exécutions identiques : aucun état ne doit être partagé de l'une à l'autre
+			for run := 1; run <= 2; run++ {
+				model.Reset()
+				result, err := societyai.RunDetailed(context.Background(), tc.mode, config, []societyai.AIModel{model})
+				if err != nil {
+					t.Fatalf("exécution %d en échec: %v", run, err)
+				}
+				estimate := societyai.EstimateModelCalls(tc.mode, config)
+				if int64(estimate) != result.ModelCalls {
+					t.Errorf("exécution %d: estimation de %d appel(s), %d effectué(s)", run, estimate, result.ModelCalls)
+				}
+				if calls := model.Calls(); int64(calls) != result.ModelCalls {
+					t.Errorf("exécution %d: ModelCalls vaut %d, le modèle a reçu %d appel(s)", run, result.ModelCalls, calls)
+				}
+			}
+		})
+	}
+}
+
+func TestRunSocietyBatchKeepsOrderAfterFailure(t *testing.T) {
+	errModel := errors.New("modèle indisponible")
+	questions := []string{"Question alpha", "Question en panne", "Question gamma"}
+	model := testmodel.New("model", func(prompt string) (string, error) {
+		if strings.Contains(prompt, "en panne") {
+			return "", errModel
+		}
+		for _, question := range questions {
+			if strings.Contains(prompt, question) {
+				return "réponse à " + question, nil
+			}
+		}
+		return "", errors.New("question inconnue")
+	})
+
+	configs := make([]*societyai.Config, len(questions))
+	for i, question := range questions {
+		configs[i] = societyai.NewConfig(question, 2)
+	}
+	responses, errs := societyai.RunSocietyBatch(context.Background(), configs, []societyai.AIModel{model})
+	if len(responses) != len(configs) || len(errs) != len(configs) {
+		t.Fatalf("%d réponse(s) et %d erreur(s) pour %d configuration(s)", len(responses), len(errs), len(configs))
+	}
+
+	if !errors.Is(errs[1], errModel) {
+		t.Errorf("erreur attendue %v pour la configuration 1, obtenu %v", errModel, errs[1])
+	}
+	for _, i := range []int{0, 2} {
+		if errs[i] != nil {
+			t.Errorf("configuration %d en échec: %v", i, errs[i])
+			continue
+		}
+		if want := "réponse à " + questions[i]; !strings.Contains(responses[i], want) {
+			t.Errorf("réponse %d = %q, attendu la mention %q", i, responses[i], want)
+		}
+	}
+}
+
+func TestNilModelRejectedBeforeAgentsStart(t *testing.T) {
+	for _, mode := range []societyai.Mode{societyai.ModeStandard, societyai.ModeSynthesis, societyai.ModeCollaborative} {
+		t.Run(string(mode), func(t *testing.T) {
+			model := testmodel.New("model", nil)
+			config := societyai.NewConfig("Comment structurer une équipe produit ?", 3)
+			config.SynthesisModel = model
+
+			_, err := societyai.RunDetailed(context.Background(), mode, config, []societyai.AIModel{model, nil})
+			if !errors.Is(err, societyai.ErrNilModel) {
+				t.Fatalf("erreur attendue %v, obtenu %v", societyai.ErrNilModel, err)
+			}
+			if calls := model.Calls(); calls != 0 {
+				t.Errorf("%d appel(s) au modèle valide, aucun attendu", calls)
+			}
+		})
+	}
+}
+
+func TestEmptySynthesisFallsBackToSimpleMethod(t *testing.T) {
+	agents := testmodel.New("agents", func(prompt string) (string, error) {
+		return "Un point de vue détaillé.", nil
+	})
+	synth := testmodel.NewScripted("synth", "  \n")
+
+	config := societyai.NewConfig("Faut-il réécrire l'application ?", 2)
+	result, err := societyai.RunSocietyWithSynthesisDetailed(context.Background(), config, []societyai.AIModel{agents}, synth)
+	if err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+
+	if !strings.Contains(result.Response, "méthode simple - synthèse vide") {
+		t.Errorf("la réponse devrait signaler le repli sur la méthode simple:\n%s", result.Response)
+	}
+	if !strings.Contains(result.Response, "Le modèle de synthèse n'a retourné aucun contenu.") {
+		t.Errorf("la réponse devrait signaler la synthèse vide:\n%s", result.Response)
+	}
+	if strings.Contains(result.Response, "via modèle de synthèse") {
+		t.Errorf("une synthèse vide ne doit pas être présentée comme une conclusion:\n%s", result.Response)
+	}
+	if calls := synth.Calls(); calls != 1 {
+		t.Errorf("%d appel(s) au modèle de synthèse, un attendu", calls)
+	}
+}
+
+func TestSynthesisJudgePromptFollowsLanguage(t *testing.T) {
+	model := testmodel.New("model", func(prompt string) (string, error) {
+		return "A reasoned answer.", nil
+	})
+	judge := testmodel.NewScripted("judge", "1")
+
+	config := societyai.NewConfig("Should we migrate to microservices?", 2)
+	config.Language = societyai.LanguageEnglish
+	config.SynthesisModel = model
+	config.SynthesisCandidates = 2
+	config.SynthesisJudge = judge
+	if _, err := societyai.RunDetailed(context.Background(), societyai.ModeSynthesis, config, []societyai.AIModel{model}); err != nil {
+		t.Fatalf("exécution en échec: %v", err)
+	}
+
+	prompts := judge.Prompts()
+	if len(prompts) != 1 {
+		t.Fatalf("%d appel(s) au juge, un attendu", len(prompts))
+	}
+	if !strings.Contains(prompts[0], "Choose the most accurate") || !strings.Contains(prompts[0], "=== Synthesis 2 ===") {
+		t.Errorf("le prompt du juge devrait être en anglais:\n%s", prompts[0])
+	}
+	if strings.Contains(prompts[0], "Synthèse") || strings.Contains(prompts[0], "Réponds") {
+		t.Errorf("le prompt du juge ne devrait contenir aucune instruction en français:\n%s", prompts[0])
+	}
+}
@@ -0,0 +1,144 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// StreamingModel est une interface optionnelle pour les modèles capables de diffuser leur réponse
+// au fil de sa génération
+type StreamingModel interface {
+	AIModel
+	// ProcessStream écrit la réponse dans w au fur et à mesure de sa génération et retourne la réponse complète
+	ProcessStream(ctx context.Context, prompt string, w io.Writer) (string, error)
+}
+
+// RunSocietyToWriters exécute les agents comme en mode standard en diffusant la réponse de chaque agent
+// dans le writer de même indice : au fil de l'eau pour un StreamingModel, d'un bloc sinon.
+// writers doit contenir au moins un writer par agent ; chaque writer n'est utilisé que par son agent,
+// un même writer partagé entre plusieurs agents doit donc être protégé par l'appelant.
+func RunSocietyToWriters(ctx context.Context, config *Config, models []AIModel, writers []io.Writer) error {
+	// Ne lancer aucun agent si le contexte est déjà annulé
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Vérifier la configuration avant de lancer les agents
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if err := validateModels(models); err != nil {
+		return err
+	}
+	if len(writers) < config.AgentCount {
+		return fmt.Errorf("%w: %d writer(s) pour %d agent(s)", ErrNotEnoughWriters, len(writers), config.AgentCount)
+	}
+	for i := 0; i < config.AgentCount; i++ {
+		if writers[i] == nil {
+			return fmt.Errorf("%w: writer %d nil", ErrNotEnoughWriters, i)
+		}
+	}
+
+	// Création de la société
+	society := createSociety(config, models)
+	society.writers = writers
+	ctx = society.startRun(ctx)
+	ctx, cancel := society.withRunTimeout(ctx)
+	defer cancel()
+	society.startProgress(ModeStandard)
+
+	// Lancement des agents
+	err := society.run(ctx)
+	society.finishProgress(PhaseAgents)
+	society.recordRun(ModeStandard, err)
+	return err
+}
+
+// processAgentToWriter traite le prompt de l'agent en diffusant sa réponse dans w
+func (s *SocietyGroup) processAgentToWriter(ctx context.Context, a *Agent, w io.Writer, opts ProcessOptions) (string, error) {
+	streaming, ok := a.Model.(StreamingModel)
+	if !ok {
+		result, err := s.callModel(ctx, a.Model, a.Prompt, opts)
+		if err != nil {
+			return "", err
+		}
+		if _, err := io.WriteString(w, result); err != nil {
+			return result, err
+		}
+		return result, nil
+	}
+
+	prompt, err := s.compressPrompt(ctx, a.Model, a.Prompt)
+	if err != nil {
+		return "", err
+	}
+	s.modelCalls.Add(1)
+	result, err := streaming.ProcessStream(ctx, prompt, w)
+	s.usage.record(a.Model)
+	return result, err
+}
+
+// AgentEvent décrit la réponse d'un agent, émise par RunSocietyStream dès que l'agent a terminé
+type AgentEvent struct {
+	AgentID   int    // Identifiant de l'agent
+	ModelName string // Nom du modèle utilisé par l'agent
+	Output    string // Réponse de l'agent (vide en cas d'échec)
+	Err       error  // Erreur de l'agent, nil en cas de succès
+}
+
+// RunSocietyStream exécute les agents comme en mode standard et émet la réponse de chaque agent
+// sur le canal retourné dès qu'elle est disponible, dans l'ordre d'arrivée ; le canal est fermé
+// une fois tous les agents terminés. Le canal peut contenir un événement par agent :
+// l'exécution n'est jamais bloquée par un lecteur lent.
+// Seules les erreurs de configuration sont retournées directement ; les échecs des agents
+// sont portés par le champ Err de leur événement.
+func RunSocietyStream(ctx context.Context, config *Config, models []AIModel) (<-chan AgentEvent, error) {
+	// Ne lancer aucun agent si le contexte est déjà annulé
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Vérifier la configuration avant de lancer les agents
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateModels(models); err != nil {
+		return nil, err
+	}
+
+	// Création de la société
+	society := createSociety(config, models)
+	events := make(chan AgentEvent, config.AgentCount)
+	society.events = events
+
+	// Lancement des agents en arrière-plan
+	go func() {
+		defer close(events)
+		ctx := society.startRun(ctx)
+		ctx, cancel := society.withRunTimeout(ctx)
+		defer cancel()
+		society.startProgress(ModeStandard)
+
+		err := society.run(ctx)
+		society.finishProgress(PhaseAgents)
+		society.recordRun(ModeStandard, err)
+	}()
+
+	return events, nil
+}
+
+// emitAgentEvent retourne une fonction qui émet la réponse de l'agent sur le canal de RunSocietyStream
+// (si la société diffuse ses réponses) puis la retourne inchangée
+func (s *SocietyGroup) emitAgentEvent(a *Agent) func(output string, err error) (string, error) {
+	return func(output string, err error) (string, error) {
+		if s.events != nil {
+			event := AgentEvent{AgentID: a.ID, ModelName: a.Model.Name(), Err: err}
+			if err == nil {
+				event.Output = output
+			}
+			s.events <- event
+		}
+		return output, err
+	}
+}
@@ -0,0 +1,313 @@
+package societyai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// synthesizeResults combine les résultats des agents en une réponse cohérente
+func synthesizeResults(results []string) string {
+	// Cette fonction pourrait être améliorée pour faire une véritable
+	// analyse et synthèse des différentes réponses
+
+	// Pour l'exemple actuel, nous faisons une simple concaténation
+	var synthesis string
+	for i, result := range results {
+		synthesis += fmt.Sprintf("\nAgent %d:\n%s\n", i+1, result)
+	}
+
+	return "Synthèse des résultats:\n" + synthesis
+}
+
+// SynthesisStrategy détermine la manière dont le modèle de synthèse combine les réponses des agents
+type SynthesisStrategy int
+
+// Stratégies de synthèse disponibles
+const (
+	// SynthesisProse fusionne les perspectives en une réponse rédigée (comportement par défaut)
+	SynthesisProse SynthesisStrategy = iota
+	// SynthesisVoting dépouille les réponses comme un vote : position majoritaire et positions minoritaires
+	SynthesisVoting
+)
+
+// SynthesizeWithModel combine les résultats des agents en utilisant un modèle spécifique
+func SynthesizeWithModel(ctx context.Context, results []string, model AIModel) (string, error) {
+	return SynthesizeWithModelInLanguage(ctx, results, model, LanguageFrench)
+}
+
+// SynthesizeWithModelInLanguage combine les résultats des agents en utilisant un modèle spécifique,
+// avec des instructions de synthèse rédigées dans la langue indiquée
+func SynthesizeWithModelInLanguage(ctx context.Context, results []string, model AIModel, lang Language) (string, error) {
+	// Utiliser le modèle fourni pour générer la synthèse
+	return model.Process(ctx, buildSynthesisPrompt(results, nil, lang, nil))
+}
+
+// buildSynthesisPrompt construit le prompt demandant au modèle de synthétiser les perspectives
+// des différents agents. numbers donne le numéro affiché de chaque agent (i+1 si nil).
+// Les consignes supplémentaires sont insérées après la tâche, juste avant l'amorce de la réponse.
+func buildSynthesisPrompt(results []string, numbers []int, lang Language, instructions []string) string {
+	return composeSynthesisPrompt(synthesisTextsFor(lang), results, numbers, instructions)
+}
+
+// SynthesizeByVoting combine les résultats des agents par vote plutôt que par fusion : le modèle extrait
+// la recommandation principale de chaque agent, compte les accords et rapporte la position majoritaire
+// ainsi que les positions minoritaires (voir Config.SynthesisStrategy)
+func SynthesizeByVoting(ctx context.Context, results []string, model AIModel) (string, error) {
+	return model.Process(ctx, buildVotingPrompt(results, nil, LanguageFrench, nil))
+}
+
+// buildVotingPrompt construit le prompt de synthèse par vote, numéroté comme buildSynthesisPrompt
+func buildVotingPrompt(results []string, numbers []int, lang Language, instructions []string) string {
+	return composeSynthesisPrompt(votingTextsFor(lang), results, numbers, instructions)
+}
+
+// composeSynthesisPrompt assemble un prompt de synthèse à partir de ses textes : en-tête, réponses des agents,
+// tâche, consignes supplémentaires puis amorce de la réponse
+func composeSynthesisPrompt(texts synthesisText, results []string, numbers []int, instructions []string) string {
+	prompt := texts.header
+
+	// Ajouter chaque résultat d'agent au prompt
+	for i, result := range results {
+		number := i + 1
+		if numbers != nil {
+			number = numbers[i]
+		}
+		prompt += fmt.Sprintf("=== %s %d ===\n%s\n\n", texts.agentLabel, number, result)
+	}
+
+	prompt += texts.task
+	for _, instruction := range instructions {
+		prompt += instruction + "\n"
+	}
+	prompt += "\n" + texts.answerLabel
+
+	return prompt
+}
+
+// synthesisInstructions retourne les consignes de synthèse issues de la configuration de la société
+func (s *SocietyGroup) synthesisInstructions(results []string) []string {
+	var instructions []string
+	if s.config == nil {
+		return instructions
+	}
+
+	lang := resolveLanguage(s.config)
+	if s.config.Audience != "" {
+		instructions = append(instructions, audienceInstruction(lang, s.config.Audience))
+	}
+	if s.config.CiteAgents {
+		instructions = append(instructions, citationInstruction(lang))
+	}
+	if s.config.ExtractiveSynthesis {
+		instructions = append(instructions, extractiveInstruction(lang))
+	}
+	if instruction := synthesisBiasInstruction(lang, s.config.SynthesisBias); instruction != "" {
+		instructions = append(instructions, instruction)
+	}
+	if s.config.AdaptiveSynthesisLength {
+		if instruction := s.synthesisLengthInstruction(lang, results); instruction != "" {
+			instructions = append(instructions, instruction)
+		}
+	}
+
+	return instructions
+}
+
+// synthesisLengthInstruction adapte la longueur demandée à l'accord entre les agents :
+// une synthèse courte lorsque la similarité moyenne atteint le seuil haut,
+// une synthèse détaillée lorsqu'elle est sous le seuil bas, aucune consigne entre les deux
+func (s *SocietyGroup) synthesisLengthInstruction(lang Language, results []string) string {
+	high := s.config.HighAgreementThreshold
+	if high == 0 {
+		high = DefaultHighAgreementThreshold
+	}
+	low := s.config.LowAgreementThreshold
+	if low == 0 {
+		low = DefaultLowAgreementThreshold
+	}
+
+	agreement := meanPairwiseSimilarity(results, s.similarity())
+	switch {
+	case agreement >= high:
+		if lang == LanguageEnglish {
+			return "The agents largely agree: keep the synthesis short, a few paragraphs at most."
+		}
+		return "Les agents sont largement d'accord : produis une synthèse courte, de quelques paragraphes au plus."
+	case agreement <= low:
+		if lang == LanguageEnglish {
+			return "The agents diverge significantly: write a detailed synthesis that explains each disagreement."
+		}
+		return "Les agents divergent fortement : produis une synthèse détaillée qui explicite chaque désaccord."
+	}
+	return ""
+}
+
+// synthesize utilise le modèle de synthèse pour combiner les résultats avec les consignes de la configuration.
+// Les agents sont numérotés d'après leur identifiant (AgentID+1), quel que soit l'ordre de présentation.
+func (s *SocietyGroup) synthesize(ctx context.Context, model AIModel, inputs []AgentResult) (string, error) {
+	lang := resolveLanguage(s.config)
+	results := make([]string, len(inputs))
+	numbers := make([]int, len(inputs))
+	for i, input := range inputs {
+		results[i] = input.Output
+		numbers[i] = input.AgentID + 1
+	}
+
+	// Présenter aussi la question posée à chaque agent, pour distinguer les divergences de cadrage
+	// des véritables désaccords
+	entries := results
+	if s.config.IncludeAgentPromptsInSynthesis {
+		entries = make([]string, len(inputs))
+		for i, input := range inputs {
+			entries[i] = agentPromptEntry(lang, input.Prompt, input.Output)
+		}
+	}
+
+	weights := s.inputWeights(inputs)
+
+	// Au-delà de Config.TreeSynthesisThreshold réponses, les synthétiser d'abord par groupes :
+	// la synthèse finale porte alors sur les synthèses intermédiaires, numérotées par groupe
+	if s.config.TreeSynthesisThreshold > 0 && len(entries) > s.config.TreeSynthesisThreshold {
+		reduced, reducedNumbers, err := reduceSynthesisLevels(ctx, entries, numbers, s.config.TreeSynthesisBranching,
+			func(ctx context.Context, group []string, groupNumbers []int) (string, error) {
+				var instructions []string
+				if groupNumbers != nil {
+					groupWeights := make([]float64, len(groupNumbers))
+					for i, number := range groupNumbers {
+						groupWeights[i] = s.config.agentWeight(number - 1)
+					}
+					if instruction := weightInstruction(lang, groupNumbers, groupWeights); instruction != "" {
+						instructions = append(instructions, instruction)
+					}
+				}
+				return s.callModel(ctx, model, buildSynthesisPrompt(group, groupNumbers, lang, instructions), ProcessOptions{})
+			})
+		if err != nil {
+			return "", err
+		}
+		if reducedNumbers == nil {
+			results, entries, numbers, weights = reduced, reduced, nil, nil
+		}
+	}
+
+	build := buildSynthesisPrompt
+	if s.config.SynthesisStrategy == SynthesisVoting {
+		build = buildVotingPrompt
+	}
+	instructions := s.synthesisInstructions(results)
+	if numbers != nil {
+		if instruction := weightInstruction(lang, numbers, weights); instruction != "" {
+			instructions = append(instructions, instruction)
+		}
+	}
+	prompt := build(entries, numbers, lang, instructions)
+	if s.config.SynthesisCandidates > 1 {
+		return s.synthesizeBestOf(ctx, model, prompt, s.config.SynthesisCandidates)
+	}
+	return s.callModel(ctx, model, prompt, ProcessOptions{})
+}
+
+// synthesizeBestOf génère n synthèses candidates à des températures réparties entre 0.3 et 1.0,
+// puis fait choisir la meilleure par Config.SynthesisJudge (le modèle de synthèse par défaut).
+// Les candidates obtenues sont conservées dans SocietyResult.SynthesisCandidates.
+func (s *SocietyGroup) synthesizeBestOf(ctx context.Context, model AIModel, prompt string, n int) (string, error) {
+	candidates := make([]string, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			temperature := 0.3 + 0.7*float64(i)/float64(n-1)
+			candidates[i], errs[i] = s.callModel(ctx, model, prompt, ProcessOptions{Temperature: temperature})
+		}(i)
+	}
+	wg.Wait()
+
+	// Écarter les candidates en échec ou vides
+	var valid []string
+	for i, candidate := range candidates {
+		if errs[i] == nil && strings.TrimSpace(candidate) != "" {
+			valid = append(valid, candidate)
+		}
+	}
+	if len(valid) == 0 {
+		return "", errors.Join(errs...)
+	}
+	s.synthesisCandidates = valid
+	if len(valid) == 1 {
+		return valid[0], nil
+	}
+
+	judge := model
+	if s.config.SynthesisJudge != nil {
+		judge = s.config.SynthesisJudge
+	}
+	selectionPrompt := candidateSelectionPrompt(resolveLanguage(s.config), valid)
+
+	verdict, err := s.callModel(ctx, judge, selectionPrompt, ProcessOptions{})
+	if err != nil {
+		// Sans sélection possible, retenir la première candidate
+		return valid[0], nil
+	}
+	if match := candidateNumberPattern.FindString(verdict); match != "" {
+		if number, err := strconv.Atoi(match); err == nil && number >= 1 && number <= len(valid) {
+			return valid[number-1], nil
+		}
+	}
+	return valid[0], nil
+}
+
+// candidateSelectionPrompt construit le prompt demandant au juge de retenir la meilleure des synthèses
+// candidates, dans la langue des instructions
+func candidateSelectionPrompt(lang Language, candidates []string) string {
+	var candidatesText string
+	for i, candidate := range candidates {
+		label := "Synthèse"
+		if lang == LanguageEnglish {
+			label = "Synthesis"
+		}
+		candidatesText += fmt.Sprintf("=== %s %d ===\n%s\n\n", label, i+1, candidate)
+	}
+
+	if lang == LanguageEnglish {
+		return "Here are several candidate syntheses of the same perspectives. " +
+			"Choose the most accurate, complete and consistent one.\n\n" + candidatesText +
+			"Answer only with the number of the best synthesis."
+	}
+	return "Voici plusieurs synthèses candidates des mêmes perspectives. " +
+		"Choisis la plus exacte, complète et cohérente.\n\n" + candidatesText +
+		"Réponds uniquement par le numéro de la meilleure synthèse."
+}
+
+// candidateNumberPattern reconnaît le numéro de synthèse choisi par le juge
+var candidateNumberPattern = regexp.MustCompile(`\d+`)
+
+// maxAgentPromptExcerpt limite la longueur du prompt d'agent repris dans le prompt de synthèse
+const maxAgentPromptExcerpt = 500
+
+// agentPromptEntry présente la question posée à un agent avec sa réponse ; la question est tronquée
+// pour préserver le budget de longueur du prompt de synthèse
+func agentPromptEntry(lang Language, prompt, output string) string {
+	excerpt := truncateRunes(prompt, maxAgentPromptExcerpt)
+	if excerpt != prompt {
+		excerpt += "…"
+	}
+	if lang == LanguageEnglish {
+		return "Question asked:\n" + excerpt + "\n\nAnswer:\n" + output
+	}
+	return "Question posée:\n" + excerpt + "\n\nRéponse:\n" + output
+}
+
+// citationInstruction retourne la consigne demandant d'attribuer chaque affirmation aux agents (Config.CiteAgents)
+func citationInstruction(lang Language) string {
+	if lang == LanguageEnglish {
+		return "After each statement, cite the agents that support it with their numbers, in the format [A1,A3]."
+	}
+	return "Après chaque affirmation, cite les agents qui la soutiennent par leur numéro, au format [A1,A3]."
+}
@@ -0,0 +1,31 @@
+package societyai
+
+import "context"
+
+// Synthesizer combine les réponses des agents en une conclusion en mode synthèse (Config.Synthesizer).
+// Il permet d'expérimenter d'autres stratégies (map-reduce, regroupement par embeddings...).
+type Synthesizer interface {
+	// Synthesize produit la conclusion à partir du prompt original et des réponses des agents non écartés
+	Synthesize(ctx context.Context, prompt string, results []string) (string, error)
+}
+
+// ModelSynthesizer est le synthétiseur par modèle : il demande au modèle, en un seul appel,
+// de synthétiser les perspectives des agents, comme SynthesizeWithModelInLanguage.
+// Sans Config.Synthesizer, la société réalise elle-même cette synthèse avec le modèle de synthèse,
+// en y ajoutant les consignes de la configuration (citations, biais, longueur adaptative...).
+type ModelSynthesizer struct {
+	Model    AIModel  // Modèle de synthèse
+	Language Language // Langue des instructions de synthèse (français si vide)
+}
+
+// Synthesize implémente l'interface Synthesizer
+func (m ModelSynthesizer) Synthesize(ctx context.Context, prompt string, results []string) (string, error) {
+	if m.Model == nil {
+		return "", ErrNoSynthesisModel
+	}
+	lang := m.Language
+	if lang == "" {
+		lang = LanguageFrench
+	}
+	return SynthesizeWithModelInLanguage(ctx, results, m.Model, lang)
+}
@@ -0,0 +1,138 @@
+// Package testmodel fournit des modèles d'IA déterministes pour tester le comportement
+// d'une société SocietyAI sans appeler de fournisseur réel.
+//
+// Les modèles supportent des réponses scriptées, des erreurs provoquées, un délai
+// configurable et le comptage des appels. Ils peuvent être utilisés en parallèle
+// par plusieurs agents.
+package testmodel
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Model est un modèle factice implémentant l'interface societyai.AIModel
+type Model struct {
+	name      string
+	responder func(prompt string) (string, error)
+	script    []string
+
+	mu          sync.Mutex
+	delay       time.Duration
+	err         error
+	failOnCalls map[int]error
+	calls       int
+	prompts     []string
+}
+
+// New crée un modèle dont les réponses sont produites par responder.
+// Un responder nil renvoie le prompt reçu tel quel.
+func New(name string, responder func(prompt string) (string, error)) *Model {
+	if responder == nil {
+		responder = func(prompt string) (string, error) {
+			return prompt, nil
+		}
+	}
+	return &Model{
+		name:        name,
+		responder:   responder,
+		failOnCalls: make(map[int]error),
+	}
+}
+
+// NewScripted crée un modèle qui renvoie les réponses fournies dans l'ordre des appels,
+// en recommençant au début une fois la liste épuisée
+func NewScripted(name string, responses ...string) *Model {
+	m := New(name, nil)
+	m.script = append([]string(nil), responses...)
+	if len(m.script) == 0 {
+		m.script = []string{""}
+	}
+	return m
+}
+
+// WithDelay fait attendre le modèle avant chaque réponse, en respectant l'annulation du contexte
+func (m *Model) WithDelay(delay time.Duration) *Model {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.delay = delay
+	return m
+}
+
+// FailWith fait échouer tous les appels avec l'erreur fournie (nil rétablit les réponses)
+func (m *Model) FailWith(err error) *Model {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+	return m
+}
+
+// FailOnCall fait échouer le n-ième appel (à partir de 1) avec l'erreur fournie
+func (m *Model) FailOnCall(n int, err error) *Model {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failOnCalls[n] = err
+	return m
+}
+
+// Name retourne le nom du modèle
+func (m *Model) Name() string {
+	return m.name
+}
+
+// Process enregistre l'appel, attend le délai configuré puis renvoie la réponse ou l'erreur prévue
+func (m *Model) Process(ctx context.Context, prompt string) (string, error) {
+	m.mu.Lock()
+	m.calls++
+	call := m.calls
+	m.prompts = append(m.prompts, prompt)
+	delay := m.delay
+	err := m.err
+	if callErr, ok := m.failOnCalls[call]; ok {
+		err = callErr
+	}
+	m.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+	if m.script != nil {
+		return m.script[(call-1)%len(m.script)], nil
+	}
+	return m.responder(prompt)
+}
+
+// Calls retourne le nombre d'appels à Process reçus
+func (m *Model) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+// Prompts retourne une copie des prompts reçus, dans l'ordre d'arrivée
+func (m *Model) Prompts() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	prompts := make([]string, len(m.prompts))
+	copy(prompts, m.prompts)
+	return prompts
+}
+
+// Reset remet à zéro le compteur d'appels et l'historique des prompts
+func (m *Model) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = 0
+	m.prompts = nil
+}
@@ -0,0 +1,92 @@
+package societyai
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultTreeSynthesisBranching est le nombre de réponses synthétisées ensemble à chaque niveau
+// d'une synthèse hiérarchique, lorsqu'il n'est pas précisé
+const DefaultTreeSynthesisBranching = 5
+
+// SynthesizeTree combine les résultats des agents par synthèses successives pour ne jamais transmettre
+// plus de branching réponses au modèle en un seul appel : les résultats sont synthétisés par groupes
+// de branching en synthèses intermédiaires, elles-mêmes synthétisées de la même manière, jusqu'à
+// l'obtention d'une synthèse unique (DefaultTreeSynthesisBranching si branching < 2).
+// Les synthèses d'un même niveau sont demandées en parallèle.
+func SynthesizeTree(ctx context.Context, results []string, model AIModel, branching int) (string, error) {
+	if model == nil {
+		return "", ErrNoSynthesisModel
+	}
+
+	synthesize := func(ctx context.Context, group []string, numbers []int) (string, error) {
+		return model.Process(ctx, buildSynthesisPrompt(group, numbers, LanguageFrench, nil))
+	}
+	reduced, _, err := reduceSynthesisLevels(ctx, results, nil, branching, synthesize)
+	if err != nil {
+		return "", err
+	}
+	return SynthesizeWithModel(ctx, reduced, model)
+}
+
+// reduceSynthesisLevels synthétise results par groupes de branching, niveau après niveau, jusqu'à ce qu'il
+// reste au plus branching textes, retournés avec leur numérotation (numbers au premier niveau, puis nil).
+// synthesize reçoit chaque groupe avec la numérotation de ses textes (nil au-delà du premier niveau).
+func reduceSynthesisLevels(ctx context.Context, results []string, numbers []int, branching int,
+	synthesize func(ctx context.Context, group []string, numbers []int) (string, error)) ([]string, []int, error) {
+	if branching < 2 {
+		branching = DefaultTreeSynthesisBranching
+	}
+
+	for len(results) > branching {
+		groups := (len(results) + branching - 1) / branching
+		next := make([]string, groups)
+		errs := make([]error, groups)
+
+		var wg sync.WaitGroup
+		for g := 0; g < groups; g++ {
+			start, end := g*branching, (g+1)*branching
+			if end > len(results) {
+				end = len(results)
+			}
+			var groupNumbers []int
+			if numbers != nil {
+				groupNumbers = numbers[start:end]
+			}
+
+			wg.Add(1)
+			go func(g int, group []string, groupNumbers []int) {
+				defer wg.Done()
+				next[g], errs[g] = synthesize(ctx, group, groupNumbers)
+			}(g, results[start:end], groupNumbers)
+		}
+		wg.Wait()
+
+		if err := errors.Join(errs...); err != nil {
+			return nil, nil, err
+		}
+		results, numbers = next, nil
+	}
+
+	return results, numbers, nil
+}
+
+// treeSynthesisCalls retourne le nombre d'appels de synthèse intermédiaires d'une synthèse hiérarchique
+// de n réponses (Config.TreeSynthesisThreshold), hors synthèse finale
+func (c *Config) treeSynthesisCalls(n int) int {
+	if c.TreeSynthesisThreshold <= 0 || n <= c.TreeSynthesisThreshold {
+		return 0
+	}
+	branching := c.TreeSynthesisBranching
+	if branching < 2 {
+		branching = DefaultTreeSynthesisBranching
+	}
+
+	calls := 0
+	for n > branching {
+		n = (n + branching - 1) / branching
+		calls += n
+	}
+	return calls
+}
@@ -0,0 +1,46 @@
+package societyai
+
+import "sync/atomic"
+
+// Usage décompte les tokens consommés par un ou plusieurs appels de modèle
+type Usage struct {
+	PromptTokens     int // Tokens envoyés au modèle
+	CompletionTokens int // Tokens générés par le modèle
+}
+
+// TotalTokens retourne le nombre total de tokens consommés
+func (u Usage) TotalTokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// UsageReporter est une interface optionnelle qu'un modèle peut implémenter pour rapporter
+// la consommation de son dernier appel. La société la lit après chaque appel et additionne
+// les consommations de l'exécution (SocietyResult.Usage, Result.TotalUsage) ; les modèles
+// qui ne l'implémentent pas comptent pour zéro. Un modèle partagé entre agents est appelé
+// simultanément : pour un décompte exact, il doit alors être attribué à un seul agent.
+type UsageReporter interface {
+	// LastUsage retourne la consommation du dernier appel du modèle
+	LastUsage() Usage
+}
+
+// usageCounter additionne les consommations des appels d'une exécution
+type usageCounter struct {
+	prompt     atomic.Int64
+	completion atomic.Int64
+}
+
+// record ajoute la consommation du dernier appel du modèle, s'il la rapporte
+func (c *usageCounter) record(model AIModel) {
+	reporter, ok := model.(UsageReporter)
+	if !ok {
+		return
+	}
+	usage := reporter.LastUsage()
+	c.prompt.Add(int64(usage.PromptTokens))
+	c.completion.Add(int64(usage.CompletionTokens))
+}
+
+// load retourne la consommation cumulée
+func (c *usageCounter) load() Usage {
+	return Usage{PromptTokens: int(c.prompt.Load()), CompletionTokens: int(c.completion.Load())}
+}
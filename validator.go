@@ -0,0 +1,52 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+)
+
+// Validator vérifie que la réponse finale respecte les contraintes du produit
+// (mention obligatoire, longueur maximale, absence de données personnelles...)
+type Validator func(answer string) error
+
+// Repair tente de corriger une réponse rejetée par le Validator, à partir de l'erreur de validation.
+// Le modèle fourni est celui qui a produit la réponse finale ; ses appels sont comptabilisés par la société.
+type Repair func(ctx context.Context, answer string, validationErr error, model AIModel) (string, error)
+
+// RepairWithModel est une implémentation de Repair qui demande au modèle de réécrire la réponse
+// en corrigeant le problème signalé par la validation
+func RepairWithModel(ctx context.Context, answer string, validationErr error, model AIModel) (string, error) {
+	prompt := fmt.Sprintf(
+		"La réponse suivante ne respecte pas une contrainte obligatoire.\n\n"+
+			"Problème détecté: %v\n\n"+
+			"Réponse à corriger:\n%s\n\n"+
+			"Réécris cette réponse en corrigeant ce problème, sans en modifier le fond. "+
+			"Retourne uniquement la réponse corrigée.",
+		validationErr, answer)
+	return model.Process(ctx, prompt)
+}
+
+// validateAnswer applique Config.Validator à la réponse finale. En cas d'échec, une seule tentative
+// de correction est effectuée avec Config.Repair ; la réponse corrigée doit à son tour être valide.
+func (s *SocietyGroup) validateAnswer(ctx context.Context, model AIModel, answer string) (string, error) {
+	if s.config == nil || s.config.Validator == nil {
+		return answer, nil
+	}
+
+	validationErr := s.config.Validator(answer)
+	if validationErr == nil {
+		return answer, nil
+	}
+	if s.config.Repair == nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidAnswer, validationErr)
+	}
+
+	repaired, err := s.config.Repair(ctx, answer, validationErr, s.wrapModel(model, ProcessOptions{}))
+	if err != nil {
+		return "", fmt.Errorf("%w: correction impossible: %w", ErrInvalidAnswer, err)
+	}
+	if err := s.config.Validator(repaired); err != nil {
+		return "", fmt.Errorf("%w: réponse corrigée toujours invalide: %w", ErrInvalidAnswer, err)
+	}
+	return repaired, nil
+}
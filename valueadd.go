@@ -0,0 +1,78 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ValueAddReport compare, sur un même prompt, la réponse du mode standard à celle du mode collaboratif
+type ValueAddReport struct {
+	StandardAnswer      string // Réponse du mode standard (juxtaposition et synthèse simple)
+	CollaborativeAnswer string // Réponse finale du mode collaboratif
+	CollaborativeBetter bool   // Le juge estime la réponse collaborative nettement meilleure
+	Rationale           string // Justification du juge
+	StandardCalls       int64  // Appels aux modèles du mode standard
+	CollaborativeCalls  int64  // Appels aux modèles du mode collaboratif
+}
+
+// ExplainValueAdd exécute le prompt en mode standard et en mode collaboratif avec agentCount agents,
+// puis demande au premier modèle de juger si la réponse collaborative est nettement meilleure,
+// pour évaluer si le surcoût du mode collaboratif se justifie sur une charge de travail donnée.
+func ExplainValueAdd(ctx context.Context, prompt string, agentCount int, models []AIModel) (*ValueAddReport, error) {
+	if err := validateModels(models); err != nil {
+		return nil, err
+	}
+
+	// Les deux modes s'exécutent en parallèle, chacun avec sa propre configuration
+	var standard, collaborative *SocietyResult
+	var standardErr, collaborativeErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		standard, standardErr = RunSocietyDetailed(ctx, NewConfig(prompt, agentCount), models)
+	}()
+	go func() {
+		defer wg.Done()
+		collaborative, collaborativeErr = runCollaborative(ctx, NewConfig(prompt, agentCount), models)
+	}()
+	wg.Wait()
+	if standardErr != nil {
+		return nil, fmt.Errorf("exécution en mode standard: %w", standardErr)
+	}
+	if collaborativeErr != nil {
+		return nil, fmt.Errorf("exécution en mode collaboratif: %w", collaborativeErr)
+	}
+
+	report := &ValueAddReport{
+		StandardAnswer:      standard.Response,
+		CollaborativeAnswer: collaborative.Response,
+		StandardCalls:       standard.ModelCalls,
+		CollaborativeCalls:  collaborative.ModelCalls,
+	}
+
+	verdict, err := models[0].Process(ctx, buildValueAddPrompt(prompt, report.StandardAnswer, report.CollaborativeAnswer))
+	if err != nil {
+		return nil, fmt.Errorf("jugement de la valeur ajoutée: %w", err)
+	}
+	choice, rationale, ok := parseDuelVerdict(verdict)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNoDuelWinner, verdict)
+	}
+	report.CollaborativeBetter = choice == "B"
+	report.Rationale = rationale
+	return report, nil
+}
+
+// buildValueAddPrompt construit le prompt demandant au juge si la réponse collaborative (B)
+// apporte une amélioration nette sur la réponse standard (A), au format du duel
+func buildValueAddPrompt(prompt, standardAnswer, collaborativeAnswer string) string {
+	return fmt.Sprintf(
+		"Compare deux réponses à la même question. La réponse B a coûté nettement plus cher à produire : "+
+			"désigne-la uniquement si elle est nettement meilleure que la réponse A (exactitude, pertinence, "+
+			"complétude, clarté) ; à qualité comparable, désigne A.\n\nQuestion:\n%s\n\n"+
+			"=== Réponse A ===\n%s\n\n=== Réponse B ===\n%s\n\n"+
+			"Réponds exactement au format suivant:\nGAGNANT: A ou B\nJUSTIFICATION: ton raisonnement",
+		prompt, standardAnswer, collaborativeAnswer)
+}
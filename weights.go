@@ -0,0 +1,77 @@
+package societyai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// agentWeight retourne le poids de l'agent dans la synthèse (Config.AgentWeights), 1 par défaut
+func (c *Config) agentWeight(agentID int) float64 {
+	if c == nil || agentID < 0 || agentID >= len(c.AgentWeights) || c.AgentWeights[agentID] == 0 {
+		return 1
+	}
+	return c.AgentWeights[agentID]
+}
+
+// SynthesizeWithModelWeighted combine les résultats des agents comme SynthesizeWithModel, en demandant
+// au modèle d'accorder à chaque perspective un poids proportionnel à weights (weights[i] pour results[i],
+// 1 pour un poids absent ou nul). Sans écart entre les poids, la synthèse est identique à SynthesizeWithModel.
+func SynthesizeWithModelWeighted(ctx context.Context, results []string, weights []float64, model AIModel) (string, error) {
+	numbers := make([]int, len(results))
+	resolved := make([]float64, len(results))
+	for i := range results {
+		numbers[i] = i + 1
+		resolved[i] = 1
+		if i < len(weights) && weights[i] != 0 {
+			resolved[i] = weights[i]
+		}
+	}
+
+	var instructions []string
+	if instruction := weightInstruction(LanguageFrench, numbers, resolved); instruction != "" {
+		instructions = append(instructions, instruction)
+	}
+	return model.Process(ctx, buildSynthesisPrompt(results, nil, LanguageFrench, instructions))
+}
+
+// inputWeights retourne le poids de chaque réponse transmise à la synthèse
+func (s *SocietyGroup) inputWeights(inputs []AgentResult) []float64 {
+	weights := make([]float64, len(inputs))
+	for i, input := range inputs {
+		weights[i] = s.config.agentWeight(input.AgentID)
+	}
+	return weights
+}
+
+// weightInstruction retourne la consigne demandant au modèle de synthèse de pondérer les perspectives
+// selon le poids de chaque agent, ou une chaîne vide lorsque tous les poids sont égaux
+func weightInstruction(lang Language, numbers []int, weights []float64) string {
+	uniform := true
+	for _, weight := range weights {
+		if weight != weights[0] {
+			uniform = false
+			break
+		}
+	}
+	if uniform {
+		return ""
+	}
+
+	label := "AGENT"
+	if texts, ok := synthesisTexts[lang]; ok {
+		label = texts.agentLabel
+	}
+	entries := make([]string, len(weights))
+	for i, weight := range weights {
+		entries[i] = fmt.Sprintf("%s %d : %s", label, numbers[i], strconv.FormatFloat(weight, 'g', -1, 64))
+	}
+
+	if lang == LanguageEnglish {
+		return "Weigh each perspective in proportion to its agent's weight (" + strings.Join(entries, ", ") +
+			"): higher-weighted agents should shape the conclusion more."
+	}
+	return "Accorde à chaque perspective une importance proportionnelle au poids de son agent (" +
+		strings.Join(entries, ", ") + ") : les agents de poids élevé doivent davantage orienter la conclusion."
+}